@@ -0,0 +1,67 @@
+// Package main provides catwalk-diff, a CLI that compares two provider
+// config files (typically the one on disk and the one a generator just
+// produced) and exits non-zero on a risky change -- a model removed, a
+// price jump, a shrinking context window, or a capability regression --
+// unless that risk category is explicitly allowed. It's meant to gate a PR
+// a generator's --diff/--check flags have already flagged as changed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk/configdiff"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/configstore"
+)
+
+func main() {
+	oldPath := flag.String("old", "", "the existing provider config (.json, .yaml or .yml)")
+	newPath := flag.String("new", "", "the freshly generated provider config to compare against it")
+	maxPriceIncrease := flag.Float64("max-price-increase", configdiff.DefaultRiskThresholds.MaxPriceIncreasePercent, "largest tolerated per-model price increase, as a percentage of the old price")
+	allow := flag.String("allow", "", "comma-separated risk categories to permit instead of failing on (model_removed, price_jump, context_shrink, capability_regression)")
+	flag.Parse()
+
+	if *oldPath == "" || *newPath == "" {
+		log.Fatal("usage: catwalk-diff --old=<path> --new=<path> [--allow=category,...]")
+	}
+
+	old, err := configstore.LoadProviderFile(*oldPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	newP, err := configstore.LoadProviderFile(*newPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	diff := configdiff.Compute(old, newP)
+	fmt.Print(diff.String())
+
+	risks := diff.Risks(configdiff.RiskThresholds{MaxPriceIncreasePercent: *maxPriceIncrease})
+	unallowed := configdiff.Unallowed(risks, parseAllow(*allow))
+	if len(unallowed) == 0 {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "\nrisky changes:")
+	for _, r := range unallowed {
+		fmt.Fprintf(os.Stderr, "  [%s] %s\n", r.Category, r.Message)
+	}
+	os.Exit(1)
+}
+
+func parseAllow(s string) []configdiff.RiskCategory {
+	if s == "" {
+		return nil
+	}
+	var categories []configdiff.RiskCategory
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			categories = append(categories, configdiff.RiskCategory(c))
+		}
+	}
+	return categories
+}
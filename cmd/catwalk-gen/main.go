@@ -0,0 +1,101 @@
+// Package main provides catwalk-gen, a single binary that regenerates every
+// provider config under internal/providers/configs/ by running each
+// registered fetcher.Source concurrently, instead of maintainers having to
+// invoke a separate per-provider cmd/<name> binary one at a time.
+//
+// Sources register themselves by importing their generators package for
+// its init() side effect; adding a new provider to this binary is just
+// adding its blank import below.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/charmbracelet/catwalk/internal/providers/generators" // registers all fetcher.Source implementations
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/fetcher"
+)
+
+func main() {
+	only := flag.String("only", "", "comma-separated provider IDs to run (default: all registered)")
+	diff := flag.Bool("diff", false, "print a structured diff against each existing config instead of just overwriting it")
+	check := flag.Bool("check", false, "exit non-zero if any fetched config differs from what's on disk, without writing")
+	timeout := flag.Duration("timeout", 60*time.Second, "per-provider fetch timeout")
+	flag.Parse()
+
+	sources := fetcher.Registered()
+	if *only != "" {
+		wanted := strings.Split(*only, ",")
+		sources = filterSources(sources, wanted)
+	}
+	if len(sources) == 0 {
+		log.Fatal("catwalk-gen: no matching providers registered")
+	}
+
+	var (
+		mu      sync.Mutex
+		drifted []catwalk.InferenceProvider
+		failed  []string
+	)
+
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source fetcher.Source) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+			defer cancel()
+
+			gen := fetcher.NewGenerator()
+			gen.Diff = *diff
+			gen.Check = *check
+
+			_, err := gen.Run(ctx, source)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, fetcher.ErrDriftDetected):
+				drifted = append(drifted, source.ProviderID())
+			case err != nil:
+				failed = append(failed, fmt.Sprintf("%s: %v", source.ProviderID(), err))
+			}
+		}(source)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		log.Fatalf("catwalk-gen: %d provider(s) failed:\n%s", len(failed), strings.Join(failed, "\n"))
+	}
+	if *check && len(drifted) > 0 {
+		sort.Slice(drifted, func(i, j int) bool { return drifted[i] < drifted[j] })
+		fmt.Printf("catwalk-gen: drift detected in %v\n", drifted)
+		os.Exit(1)
+	}
+}
+
+// filterSources returns the subset of sources whose ProviderID is in ids.
+func filterSources(sources []fetcher.Source, ids []string) []fetcher.Source {
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[strings.TrimSpace(id)] = true
+	}
+
+	var filtered []fetcher.Source
+	for _, source := range sources {
+		if want[string(source.ProviderID())] {
+			filtered = append(filtered, source)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,70 @@
+// Package main runs the provider catalog server: the same JSON/HTTP routes
+// as the root catwalk binary, plus a gRPC ProviderCatalogService (see
+// pkg/catwalkpb) for clients that want a typed, streamable alternative to
+// polling /v2/providers.
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/catwalk/internal/httpapi"
+	"github.com/charmbracelet/catwalk/internal/providers"
+	"github.com/charmbracelet/catwalk/pkg/catwalkpb"
+	"google.golang.org/grpc"
+)
+
+// refreshInterval is how often the provider registry re-fetches its live
+// sources.
+const refreshInterval = 15 * time.Minute
+
+const (
+	httpAddr = ":8080"
+	grpcAddr = ":8081"
+)
+
+func main() {
+	registry, err := providers.NewRegistry()
+	if err != nil {
+		log.Fatal("Failed to build provider registry:", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go registry.Run(ctx, refreshInterval)
+
+	errc := make(chan error, 2)
+	go func() { errc <- serveHTTP(registry) }()
+	go func() { errc <- serveGRPC(registry) }()
+
+	log.Fatal(<-errc)
+}
+
+func serveHTTP(registry *providers.Registry) error {
+	server := &http.Server{
+		Addr:         httpAddr,
+		Handler:      httpapi.NewMux(registry),
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	log.Println("HTTP server starting on", httpAddr)
+	return server.ListenAndServe() //nolint:wrapcheck
+}
+
+func serveGRPC(registry *providers.Registry) error {
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	srv := grpc.NewServer()
+	catwalkpb.RegisterProviderCatalogServiceServer(srv, catwalkpb.NewServer(registry))
+
+	log.Println("gRPC server starting on", grpcAddr)
+	return srv.Serve(lis) //nolint:wrapcheck
+}
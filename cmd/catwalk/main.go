@@ -0,0 +1,89 @@
+// Package main provides the catwalk CLI, which wraps the provider sync
+// subsystem so maintainers can regenerate a provider's config with a single
+// command instead of running a per-provider tool.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/charmbracelet/catwalk/internal/providers/sync"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/configstore"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("usage: catwalk sync --provider=<name> | catwalk config convert --in=<path> --out=<path>")
+	}
+
+	switch os.Args[1] {
+	case "sync":
+		runSync(os.Args[2:])
+	case "config":
+		runConfig(os.Args[2:])
+	default:
+		log.Fatalf("unknown command %q", os.Args[1])
+	}
+}
+
+func runConfig(args []string) {
+	if len(args) < 1 {
+		log.Fatal("usage: catwalk config convert --in=<path> --out=<path>")
+	}
+
+	switch args[0] {
+	case "convert":
+		runConfigConvert(args[1:])
+	default:
+		log.Fatalf("unknown config subcommand %q", args[0])
+	}
+}
+
+// runConfigConvert round-trips a single-provider config between JSON and
+// YAML, inferring each side's format from its file extension. It validates
+// the decoded provider before writing it out, so a typo'd hand-authored
+// providers.yaml fails fast instead of silently producing a broken JSON
+// config (or vice versa).
+func runConfigConvert(args []string) {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	in := fs.String("in", "", "input provider config (.json, .yaml or .yml)")
+	out := fs.String("out", "", "output provider config (.json, .yaml or .yml)")
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+	if *in == "" || *out == "" {
+		log.Fatal("--in and --out are required")
+	}
+
+	provider, err := configstore.LoadProviderFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := configstore.Validate(provider); err != nil {
+		log.Fatal(err)
+	}
+	if err := configstore.SaveProviderFile(*out, provider); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Converted %s -> %s\n", *in, *out)
+}
+
+func runSync(args []string) {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	provider := fs.String("provider", "", "provider source to sync, e.g. synthetic")
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+	if *provider == "" {
+		log.Fatal("--provider is required")
+	}
+
+	if err := sync.Run(context.Background(), *provider); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Synced %s\n", *provider)
+}
@@ -1,10 +1,23 @@
-// Package main provides a command-line tool to fetch models from Hugging Face Router
-// and generate a configuration file for the provider.
+// Package main provides a command-line tool to fetch models from Hugging
+// Face Router and generate a configuration file for the provider.
+//
+// Like cmd/apipie, it uses pkg/enrich (Heuristic first, falling back to an
+// LLM via internal/infer, offline-capable) to generate display names and
+// classify reasoning-effort support, backed by a SQLite cache so repeated
+// runs don't re-hit the LLM for a model whose card hasn't changed.
+//
+// Set HUGGINGFACE_API_KEY to authenticate model-card lookups against the
+// Hugging Face Hub (raises the otherwise low anonymous rate limit) and
+// HUGGINGFACE_INFERENCE_ENDPOINT to point them at a different Hub-compatible
+// host. Set APIPIE_DISPLAY_NAME_API_KEY to enable the same LLM-generated
+// display names cmd/apipie uses; without it, names fall back to the model
+// ID and reasoning-effort classification falls back to static heuristics.
 package main
 
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -14,9 +27,16 @@ import (
 	"strings"
 	"time"
 
-	"charm.land/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/internal/infer"
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/enrich"
 )
 
+// enricher performs the LLM-assisted display-name and reasoning-effort
+// inference, the same pkg/enrich.Chain cmd/apipie uses. It runs in offline
+// mode (static heuristics only) when APIPIE_DISPLAY_NAME_API_KEY isn't set.
+var enricher *enrich.Chain
+
 // SupportedProviders defines which providers we want to support.
 // Add or remove providers from this slice to control which ones are included.
 var SupportedProviders = []string{
@@ -64,6 +84,25 @@ type ModelsResponse struct {
 	Data   []Model `json:"data"`
 }
 
+// ModelCard is the subset of the Hugging Face Hub model-info response
+// (GET /api/models/{id}) used to classify a model's capabilities.
+type ModelCard struct {
+	PipelineTag string   `json:"pipeline_tag,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	CardData    struct {
+		Summary string `json:"summary,omitempty"`
+	} `json:"cardData,omitempty"`
+}
+
+// hubEndpoint returns the Hugging Face Hub host to query for model info,
+// honoring HUGGINGFACE_INFERENCE_ENDPOINT for self-hosted/mirrored hubs.
+func hubEndpoint() string {
+	if endpoint := os.Getenv("HUGGINGFACE_INFERENCE_ENDPOINT"); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/")
+	}
+	return "https://huggingface.co"
+}
+
 func fetchHuggingFaceModels() (*ModelsResponse, error) {
 	client := &http.Client{Timeout: 30 * time.Second}
 	req, _ := http.NewRequestWithContext(
@@ -73,6 +112,9 @@ func fetchHuggingFaceModels() (*ModelsResponse, error) {
 		nil,
 	)
 	req.Header.Set("User-Agent", "Crush-Client/1.0")
+	if apiKey := os.Getenv("HUGGINGFACE_API_KEY"); apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err //nolint:wrapcheck
@@ -89,6 +131,39 @@ func fetchHuggingFaceModels() (*ModelsResponse, error) {
 	return &mr, nil
 }
 
+// fetchModelCard fetches modelID's card from the Hugging Face Hub. A
+// non-200 response (e.g. a gated or removed model) returns a zero ModelCard
+// rather than an error, since a missing card shouldn't fail the whole run.
+func fetchModelCard(modelID string) ModelCard {
+	client := &http.Client{Timeout: 15 * time.Second}
+	req, _ := http.NewRequestWithContext(
+		context.Background(),
+		"GET",
+		hubEndpoint()+"/api/models/"+modelID,
+		nil,
+	)
+	if apiKey := os.Getenv("HUGGINGFACE_API_KEY"); apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Printf("Failed to fetch model card for %s: %v", modelID, err)
+		return ModelCard{}
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != 200 {
+		return ModelCard{}
+	}
+
+	var card ModelCard
+	if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+		log.Printf("Failed to decode model card for %s: %v", modelID, err)
+		return ModelCard{}
+	}
+	return card
+}
+
 // findContextWindow looks for a context window from any provider for the given model.
 func findContextWindow(model Model) int64 {
 	for _, provider := range model.Providers {
@@ -99,9 +174,52 @@ func findContextWindow(model Model) int64 {
 	return 0
 }
 
+// toRawModel converts modelID and its card into the generator-agnostic
+// shape pkg/enrich needs. The pipeline tag is folded into InputModalities
+// too, not just Subtype: Heuristic's image detection matches "image" in a
+// modality but "image-text-to-text"/"image-to-text" tags don't otherwise
+// contain "vision" or "multimodal" for it to match on.
+func toRawModel(modelID string, card ModelCard) enrich.RawModel {
+	rm := enrich.RawModel{
+		ID:          modelID,
+		BaseModel:   modelID,
+		Subtype:     card.PipelineTag,
+		Tags:        card.Tags,
+		Description: card.CardData.Summary,
+	}
+	if strings.Contains(card.PipelineTag, "image") {
+		rm.InputModalities = append(rm.InputModalities, "image")
+	}
+	return rm
+}
+
+// huggingfaceCachePath is where the display-name/reasoning-effort cache
+// lives, relative to the repo root this tool is run from.
+const huggingfaceCachePath = "cmd/huggingface/cache.db"
+
 // WARN: DO NOT USE
 // for now we have a subset list of models we use.
 func main() {
+	resetCache := flag.Bool("reset-cache", false, "delete the display-name/reasoning-effort cache before running")
+	flag.Parse()
+
+	if *resetCache {
+		if err := os.Remove(huggingfaceCachePath); err != nil && !os.IsNotExist(err) {
+			log.Fatal("Error resetting cache:", err)
+		}
+	}
+
+	cache, err := enrich.NewCache(huggingfaceCachePath)
+	if err != nil {
+		log.Fatal("Error initializing cache:", err)
+	}
+	defer cache.Close()
+	enricher = enrich.NewChain(enrich.NewLLM(infer.NewAnalyzer(os.Getenv("APIPIE_DISPLAY_NAME_API_KEY")), cache))
+
+	if err := cache.CleanOldEntries(30 * 24 * time.Hour); err != nil {
+		log.Printf("Warning: Failed to clean old cache entries: %v", err)
+	}
+
 	modelsResp, err := fetchHuggingFaceModels()
 	if err != nil {
 		log.Fatal("Error fetching Hugging Face models:", err)
@@ -113,7 +231,7 @@ func main() {
 		APIKey:              "$HF_TOKEN",
 		APIEndpoint:         "https://router.huggingface.co/v1",
 		Type:                catwalk.TypeOpenAICompat,
-		DefaultLargeModelID: "moonshotai/Kimi-K2-Instruct-0905:groq",
+		DefaultLargeModelID: "moonshotai/Kimi-K2-Instruct-0905",
 		DefaultSmallModelID: "openai/gpt-oss-20b",
 		Models:              []catwalk.Model{},
 		DefaultHeaders: map[string]string{
@@ -130,59 +248,90 @@ func main() {
 			continue
 		}
 
+		card := fetchModelCard(model.ID)
+		rawModel := toRawModel(model.ID, card)
+		names := enricher.DisplayNames(context.Background(), []enrich.RawModel{rawModel})
+		name := names[rawModel.CacheKey()]
+		if name == "" {
+			name = model.ID
+		}
+		caps := enricher.Classify(context.Background(), rawModel)
+
+		// Build one ModelOffering per live, supported provider that serves
+		// this model, instead of a synthetic "id:provider" Model per
+		// provider -- they're the same weights, just reachable through
+		// different routes with their own pricing and context cap.
+		var offerings []catwalk.ModelOffering
+		supportsStructuredOutput := true
 		for _, provider := range model.Providers {
-			// Skip unsupported providers
 			if !slices.Contains(SupportedProviders, provider.Provider) {
 				continue
 			}
-
-			// Skip providers that don't support tools
 			if !provider.SupportsTools {
 				continue
 			}
-
-			// Skip non-live providers
 			if provider.Status != "live" {
 				continue
 			}
 
-			// Create model with provider-specific ID and name
-			modelID := fmt.Sprintf("%s:%s", model.ID, provider.Provider)
-			modelName := fmt.Sprintf("%s (%s)", model.ID, provider.Provider)
-
-			// Use provider's context length, or fallback if not available
 			contextLength := provider.ContextLength
 			if contextLength == 0 {
 				contextLength = fallbackContextLength
 			}
 
-			// Calculate pricing (convert from per-token to per-1M tokens)
 			var costPer1MIn, costPer1MOut float64
 			if provider.Pricing != nil {
 				costPer1MIn = provider.Pricing.Input
 				costPer1MOut = provider.Pricing.Output
 			}
 
-			// Set default max tokens (conservative estimate)
-			defaultMaxTokens := min(contextLength/4, 8192)
-
-			m := catwalk.Model{
-				ID:                 modelID,
-				Name:               modelName,
-				CostPer1MIn:        costPer1MIn,
-				CostPer1MOut:       costPer1MOut,
-				CostPer1MInCached:  0, // Not provided by HF Router
-				CostPer1MOutCached: 0, // Not provided by HF Router
-				ContextWindow:      contextLength,
-				DefaultMaxTokens:   defaultMaxTokens,
-				CanReason:          false, // Not provided by HF Router
-				SupportsImages:     false, // Not provided by HF Router
-			}
+			offerings = append(offerings, catwalk.ModelOffering{
+				InferenceProvider: catwalk.InferenceProvider(provider.Provider),
+				ModelID:           fmt.Sprintf("%s:%s", model.ID, provider.Provider),
+				ContextWindow:     contextLength,
+				CostPer1MIn:       costPer1MIn,
+				CostPer1MOut:      costPer1MOut,
+			})
+			supportsStructuredOutput = supportsStructuredOutput && provider.SupportsStructuredOutput
+		}
+		if len(offerings) == 0 {
+			fmt.Printf("Skipping model %s - no supported live provider offers it\n", model.ID)
+			continue
+		}
 
-			hfProvider.Models = append(hfProvider.Models, m)
-			fmt.Printf("Added model %s with context window %d from provider %s\n",
-				modelID, contextLength, provider.Provider)
+		inputModalities := []catwalk.Modality{catwalk.ModalityText}
+		if caps.SupportsImages {
+			inputModalities = append(inputModalities, catwalk.ModalityImage)
 		}
+
+		m := catwalk.Model{
+			ID:               model.ID,
+			Name:             name,
+			ContextWindow:    fallbackContextLength,
+			DefaultMaxTokens: min(fallbackContextLength/4, 8192),
+			CanReason:        caps.CanReason,
+			SupportsImages:   caps.SupportsImages,
+			Offerings:        offerings,
+			Capabilities: catwalk.Capabilities{
+				// Every surviving offering above already filtered on
+				// provider.SupportsTools, so the model itself supports tools.
+				SupportsTools:            true,
+				SupportsStructuredOutput: supportsStructuredOutput,
+				SupportsStreaming:        true,
+				InputModalities:          inputModalities,
+				OutputModalities:         []catwalk.Modality{catwalk.ModalityText},
+			},
+		}
+		if caps.HasReasoningEffort {
+			m.DefaultReasoningEffort = "medium"
+		}
+		if cheapest, ok := m.CheapestOffering(); ok {
+			m.CostPer1MIn = cheapest.CostPer1MIn
+			m.CostPer1MOut = cheapest.CostPer1MOut
+		}
+
+		hfProvider.Models = append(hfProvider.Models, m)
+		fmt.Printf("Added model %s with %d provider offering(s)\n", m.ID, len(offerings))
 	}
 
 	slices.SortFunc(hfProvider.Models, func(a catwalk.Model, b catwalk.Model) int {
@@ -199,5 +348,9 @@ func main() {
 		log.Fatal("Error writing Hugging Face provider config:", err)
 	}
 
+	if finalCount, err := cache.GetStats(); err == nil {
+		log.Printf("Cache now contains %d entries", finalCount)
+	}
+
 	fmt.Printf("Generated huggingface.json with %d models\n", len(hfProvider.Models))
 }
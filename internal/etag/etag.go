@@ -1,13 +1,173 @@
-// Package etag can create the etag value for the given data.
+// Package etag can create the etag value for the given data, and parse and
+// match it against an If-None-Match/If-Match header per RFC 9110 §8.8.
 package etag
 
 import (
 	"crypto/sha256"
 	"fmt"
+	"os"
+	"strings"
 )
 
-// Of returns the etag for the given data.
+// Of returns the etag fingerprint for the given data: a bare, unquoted hex
+// digest suitable for the FileStore/sidecar comparisons in this package.
+// HTTP handlers that need an actual `ETag` header value want Strong or Weak
+// instead.
 func Of(data []byte) string {
 	hash := sha256.Sum256(data)
 	return fmt.Sprintf(`%x`, hash[:16])
 }
+
+// Tag is a single entity-tag as carried by an ETag, If-Match, or
+// If-None-Match header: an opaque Value plus whether it's weak (`W/"..."`)
+// or strong (`"..."`).
+type Tag struct {
+	Value string
+	Weak  bool
+}
+
+// String renders t in HTTP syntax: `"value"` for a strong tag, `W/"value"`
+// for a weak one.
+func (t Tag) String() string {
+	if t.Weak {
+		return `W/"` + t.Value + `"`
+	}
+	return `"` + t.Value + `"`
+}
+
+// Strong returns data's fingerprint as a strong ETag header value, e.g.
+// `"1a2b3c"`. Use this for a response whose body is byte-for-byte what the
+// tag claims.
+func Strong(data []byte) string {
+	return Tag{Value: Of(data)}.String()
+}
+
+// Weak returns data's fingerprint as a weak ETag header value, e.g.
+// `W/"1a2b3c"`. Use this when the representation is semantically
+// equivalent to what generated the tag but not necessarily byte-identical
+// (e.g. it omits fields a strict diff would care about).
+func Weak(data []byte) string {
+	return Tag{Value: Of(data), Weak: true}.String()
+}
+
+// ParseTag parses a single entity-tag (`"value"`, `W/"value"`, or the `*`
+// wildcard) as it appears inside an If-Match/If-None-Match header.
+func ParseTag(s string) Tag {
+	s = strings.TrimSpace(s)
+	if s == "*" {
+		return Tag{Value: "*"}
+	}
+
+	weak := false
+	if rest, ok := strings.CutPrefix(s, "W/"); ok {
+		weak = true
+		s = rest
+	}
+	return Tag{Value: strings.Trim(s, `"`), Weak: weak}
+}
+
+// Parse splits an If-Match/If-None-Match header value into its Tags,
+// respecting commas inside quoted tag values and the `*` wildcard (returned
+// as a single Tag with Value "*"). An empty header parses to nil.
+func Parse(header string) []Tag {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil
+	}
+	if header == "*" {
+		return []Tag{{Value: "*"}}
+	}
+
+	var tags []Tag
+	var field strings.Builder
+	inQuotes := false
+	flush := func() {
+		s := strings.TrimSpace(field.String())
+		field.Reset()
+		if s != "" {
+			tags = append(tags, ParseTag(s))
+		}
+	}
+	for _, r := range header {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			field.WriteRune(r)
+		case r == ',' && !inQuotes:
+			flush()
+		default:
+			field.WriteRune(r)
+		}
+	}
+	flush()
+	return tags
+}
+
+// Match reports whether current matches any of candidates, per RFC 9110
+// §8.8.3.2. The `*` wildcard matches unconditionally. allowWeak selects weak
+// comparison (values equal, weak/strong ignored -- what GET/HEAD
+// conditional requests use) over strong comparison (values equal and
+// neither tag is weak -- what If-Match/range requests require).
+func Match(current Tag, candidates []Tag, allowWeak bool) bool {
+	for _, c := range candidates {
+		if c.Value == "*" {
+			return true
+		}
+		if c.Value != current.Value {
+			continue
+		}
+		if !allowWeak && (c.Weak || current.Weak) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// FileStore provides conditional writes of a generated file, so a run that
+// produces byte-identical output doesn't touch the file (or git history) at
+// all.
+type FileStore struct {
+	Path string
+}
+
+// WriteIfChanged compares Of(data) against the etag of whatever is already
+// at s.Path and only replaces it (atomically, via a temp file + rename) when
+// they differ. It reports whether a write happened.
+func (s FileStore) WriteIfChanged(data []byte) (bool, error) {
+	old, err := os.ReadFile(s.Path)
+	if err == nil && Of(old) == Of(data) {
+		return false, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("reading %s: %w", s.Path, err)
+	}
+
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return false, fmt.Errorf("writing %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, s.Path); err != nil {
+		return false, fmt.Errorf("replacing %s: %w", s.Path, err)
+	}
+	return true, nil
+}
+
+// ReadSidecar returns the etag recorded in path+".etag" by a previous
+// WriteSidecar call, or "" if there isn't one yet.
+func ReadSidecar(path string) string {
+	data, err := os.ReadFile(path + ".etag")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// WriteSidecar records value in path+".etag", for a future ReadSidecar call
+// to send back upstream as If-None-Match.
+func WriteSidecar(path, value string) error {
+	if err := os.WriteFile(path+".etag", []byte(value), 0o600); err != nil {
+		return fmt.Errorf("writing %s.etag: %w", path, err)
+	}
+	return nil
+}
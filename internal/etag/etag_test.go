@@ -0,0 +1,66 @@
+package etag
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []Tag
+	}{
+		{``, nil},
+		{`*`, []Tag{{Value: "*"}}},
+		{`"abc"`, []Tag{{Value: "abc"}}},
+		{`W/"abc"`, []Tag{{Value: "abc", Weak: true}}},
+		{`"abc", "def"`, []Tag{{Value: "abc"}, {Value: "def"}}},
+		{`"abc", W/"def"`, []Tag{{Value: "abc"}, {Value: "def", Weak: true}}},
+	}
+	for _, tt := range tests {
+		got := Parse(tt.header)
+		if len(got) != len(tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.header, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Parse(%q)[%d] = %v, want %v", tt.header, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   Tag
+		candidate string
+		allowWeak bool
+		want      bool
+	}{
+		{"wildcard always matches", Tag{Value: "abc"}, `*`, false, true},
+		{"strong equal matches strong", Tag{Value: "abc"}, `"abc"`, false, true},
+		{"strong equal matches among multiple", Tag{Value: "abc"}, `"xyz", "abc"`, false, true},
+		{"weak candidate fails strong comparison", Tag{Value: "abc"}, `W/"abc"`, false, false},
+		{"weak candidate passes weak comparison", Tag{Value: "abc"}, `W/"abc"`, true, true},
+		{"weak current passes weak comparison", Tag{Value: "abc", Weak: true}, `"abc"`, true, true},
+		{"weak current fails strong comparison", Tag{Value: "abc", Weak: true}, `"abc"`, false, false},
+		{"different value never matches", Tag{Value: "abc"}, `"def"`, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := Parse(tt.candidate)
+			if got := Match(tt.current, candidates, tt.allowWeak); got != tt.want {
+				t.Errorf("Match(%v, %v, %v) = %v, want %v", tt.current, candidates, tt.allowWeak, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrongWeak(t *testing.T) {
+	data := []byte("hello")
+	if s := Strong(data); s != `"`+Of(data)+`"` {
+		t.Errorf("Strong(data) = %q, want %q", s, `"`+Of(data)+`"`)
+	}
+	if w := Weak(data); w != `W/"`+Of(data)+`"` {
+		t.Errorf("Weak(data) = %q, want %q", w, `W/"`+Of(data)+`"`)
+	}
+}
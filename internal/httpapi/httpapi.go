@@ -0,0 +1,416 @@
+// Package httpapi builds the HTTP mux that serves the provider catalog
+// (JSON over /v2/providers and friends, plus the deprecated /providers
+// route) from a *providers.Registry. It backs both the root server binary
+// and cmd/catwalk-server, which additionally serves the catalog over gRPC
+// (see pkg/catwalkpb).
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/internal/deprecated"
+	"github.com/charmbracelet/catwalk/internal/etag"
+	"github.com/charmbracelet/catwalk/internal/providers"
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// providersCacheMaxAge and providersCacheStaleWhileRevalidate size the
+// Cache-Control sent with the full, unfiltered /v2/providers response:
+// short enough that a client picks up a background refresh within a
+// minute or so, long enough that a CDN or browser cache absorbs most of
+// the poll-on-startup traffic from Crush installs.
+const (
+	providersCacheMaxAge               = 60
+	providersCacheStaleWhileRevalidate = 300
+)
+
+var requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "catwalk",
+	Subsystem: "providers",
+	Name:      "requests_total",
+	Help:      "Total number of requests to the providers endpoints, labeled by route and provider.",
+}, []string{"route", "provider"})
+
+var responseBytes = promauto.NewHistogram(prometheus.HistogramOpts{
+	Namespace: "catwalk",
+	Subsystem: "providers",
+	Name:      "response_bytes",
+	Help:      "Size in bytes of the body written for GET /v2/providers, after content-encoding negotiation.",
+	Buckets:   prometheus.ExponentialBuckets(1024, 2, 10), // 1KiB .. 512KiB
+})
+
+var etagHitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "catwalk",
+	Subsystem: "providers",
+	Name:      "etag_hits_total",
+	Help:      "Total number of GET /v2/providers requests answered with 304 Not Modified.",
+})
+
+var etagMissesTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "catwalk",
+	Subsystem: "providers",
+	Name:      "etag_misses_total",
+	Help:      "Total number of GET /v2/providers requests that required writing a full body.",
+})
+
+// NewMux builds the HTTP handler serving registry's catalog, plus /healthz
+// and /metrics.
+func NewMux(registry *providers.Registry) *http.ServeMux {
+	h := &handler{registry: registry}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/providers", h.providers)
+	mux.HandleFunc("/v2/providers/{id}", h.provider)
+	mux.HandleFunc("/v2/providers/{id}/models", h.providerModels)
+	mux.HandleFunc("/v2/models/{id}", h.model)
+	mux.HandleFunc("/providers", h.providersDeprecated)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+type handler struct {
+	registry *providers.Registry
+}
+
+// filters narrows a providers/models listing down to the entries matching
+// the query parameters a caller cares about. A zero filters matches
+// everything.
+type filters struct {
+	supportsReasoning *bool
+	supportsImages    *bool
+	maxCostPer1MIn    *float64
+	minContextWindow  *int64
+	providerType      catwalk.Type
+}
+
+// active reports whether any filter is set, so callers can skip re-encoding
+// the unfiltered payload.
+func (f filters) active() bool {
+	return f.supportsReasoning != nil || f.supportsImages != nil ||
+		f.maxCostPer1MIn != nil || f.minContextWindow != nil || f.providerType != ""
+}
+
+// matchesModel reports whether m passes every model-level filter in f.
+func (f filters) matchesModel(m catwalk.Model) bool {
+	if f.supportsReasoning != nil && m.CanReason != *f.supportsReasoning {
+		return false
+	}
+	if f.supportsImages != nil && m.SupportsImages != *f.supportsImages {
+		return false
+	}
+	if f.maxCostPer1MIn != nil && m.CostPer1MIn > *f.maxCostPer1MIn {
+		return false
+	}
+	if f.minContextWindow != nil && m.ContextWindow < *f.minContextWindow {
+		return false
+	}
+	return true
+}
+
+// parseFilters reads the supported query parameters off q:
+// supports_reasoning, supports_images, max_cost_per_1m_in,
+// min_context_window and type.
+func parseFilters(q url.Values) (filters, error) {
+	var f filters
+	if v := q.Get("supports_reasoning"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return filters{}, fmt.Errorf("invalid supports_reasoning: %w", err)
+		}
+		f.supportsReasoning = &b
+	}
+	if v := q.Get("supports_images"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return filters{}, fmt.Errorf("invalid supports_images: %w", err)
+		}
+		f.supportsImages = &b
+	}
+	if v := q.Get("max_cost_per_1m_in"); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return filters{}, fmt.Errorf("invalid max_cost_per_1m_in: %w", err)
+		}
+		f.maxCostPer1MIn = &n
+	}
+	if v := q.Get("min_context_window"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return filters{}, fmt.Errorf("invalid min_context_window: %w", err)
+		}
+		f.minContextWindow = &n
+	}
+	if v := q.Get("type"); v != "" {
+		f.providerType = catwalk.Type(v)
+	}
+	return f, nil
+}
+
+// filterModels returns the subset of models matching f's model-level
+// filters. If no such filter is set, models is returned as-is.
+func filterModels(models []catwalk.Model, f filters) []catwalk.Model {
+	if !f.active() {
+		return models
+	}
+	out := make([]catwalk.Model, 0, len(models))
+	for _, m := range models {
+		if f.matchesModel(m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// filterProviders returns the providers matching f.providerType, each with
+// its Models narrowed down by filterModels.
+func filterProviders(provs []catwalk.Provider, f filters) []catwalk.Provider {
+	out := make([]catwalk.Provider, 0, len(provs))
+	for _, p := range provs {
+		if f.providerType != "" && p.Type != f.providerType {
+			continue
+		}
+		p.Models = filterModels(p.Models, f)
+		out = append(out, p)
+	}
+	return out
+}
+
+// findProvider returns the provider with the given ID, if any.
+func findProvider(provs []catwalk.Provider, id catwalk.InferenceProvider) (catwalk.Provider, bool) {
+	for _, p := range provs {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return catwalk.Provider{}, false
+}
+
+// writeJSONResponse sets the ETag/Content-Type headers, handles HEAD and
+// If-None-Match, counts the request under route/providerID, and writes
+// data for a GET that isn't a cache hit. If-None-Match is matched weakly,
+// as required for a GET conditional request (RFC 9110 §13.1.1): a client
+// that sent back a weak validator still gets its 304.
+func writeJSONResponse(w http.ResponseWriter, r *http.Request, route, providerID string, data []byte, etagValue string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etagValue)
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	requestsTotal.WithLabelValues(route, providerID).Inc()
+
+	current := etag.ParseTag(etagValue)
+	if candidates := etag.Parse(r.Header.Get("If-None-Match")); etag.Match(current, candidates, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Error writing response: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// providers serves the full provider catalog, narrowed by any filters in
+// the query string.
+func (h *handler) providers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	f, err := parseFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !f.active() {
+		h.writeProvidersList(w, r)
+		return
+	}
+
+	filtered := filterProviders(h.registry.Providers(), f)
+	data, err := json.Marshal(filtered)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSONResponse(w, r, "providers", "", data, etag.Strong(data))
+}
+
+// writeProvidersList serves the full, unfiltered catalog. Unlike
+// writeJSONResponse, it negotiates a precomputed gzip/zstd encoding (see
+// Registry.publish) instead of compressing on every request, and adds the
+// Cache-Control/Vary/Last-Modified headers and etag hit/miss metrics that
+// only make sense for this, the hottest and heaviest, route.
+func (h *handler) writeProvidersList(w http.ResponseWriter, r *http.Request) {
+	tag := h.registry.ETag()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", tag)
+	w.Header().Set("Vary", "Accept-Encoding")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", providersCacheMaxAge, providersCacheStaleWhileRevalidate))
+	w.Header().Set("Last-Modified", h.registry.LastModified().UTC().Format(http.TimeFormat))
+
+	body := h.registry.JSON()
+	switch acceptEncoding := r.Header.Get("Accept-Encoding"); {
+	case strings.Contains(acceptEncoding, "zstd"):
+		w.Header().Set("Content-Encoding", "zstd")
+		body = h.registry.Zstd()
+	case strings.Contains(acceptEncoding, "gzip"):
+		w.Header().Set("Content-Encoding", "gzip")
+		body = h.registry.Gzip()
+	}
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	requestsTotal.WithLabelValues("providers", "").Inc()
+
+	current := etag.ParseTag(tag)
+	if candidates := etag.Parse(r.Header.Get("If-None-Match")); etag.Match(current, candidates, true) {
+		etagHitsTotal.Inc()
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	etagMissesTotal.Inc()
+
+	responseBytes.Observe(float64(len(body)))
+	if _, err := w.Write(body); err != nil {
+		log.Printf("Error writing response: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// provider serves a single provider by ID.
+func (h *handler) provider(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	f, err := parseFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := catwalk.InferenceProvider(r.PathValue("id"))
+	provider, ok := findProvider(h.registry.Providers(), id)
+	if !ok || (f.providerType != "" && provider.Type != f.providerType) {
+		http.Error(w, "provider not found", http.StatusNotFound)
+		return
+	}
+	provider.Models = filterModels(provider.Models, f)
+
+	data, err := json.Marshal(provider)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSONResponse(w, r, "provider", string(id), data, etag.Strong(data))
+}
+
+// providerModels serves the models of a single provider by ID.
+func (h *handler) providerModels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	f, err := parseFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := catwalk.InferenceProvider(r.PathValue("id"))
+	provider, ok := findProvider(h.registry.Providers(), id)
+	if !ok || (f.providerType != "" && provider.Type != f.providerType) {
+		http.Error(w, "provider not found", http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(filterModels(provider.Models, f))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSONResponse(w, r, "provider_models", string(id), data, etag.Strong(data))
+}
+
+// model serves every model across all providers that matches the given
+// model ID, since model IDs aren't guaranteed unique across providers.
+func (h *handler) model(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	f, err := parseFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := r.PathValue("id")
+	var matches []catwalk.Model
+	for _, p := range h.registry.Providers() {
+		for _, m := range p.Models {
+			if m.ID == id && f.matchesModel(m) {
+				matches = append(matches, m)
+			}
+		}
+	}
+	if len(matches) == 0 {
+		http.Error(w, "model not found", http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(matches)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSONResponse(w, r, "model", "", data, etag.Strong(data))
+}
+
+func (h *handler) providersDeprecated(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	requestsTotal.WithLabelValues("providers_deprecated", "").Inc()
+	allProviders := deprecated.GetAll()
+	if err := json.NewEncoder(w).Encode(allProviders); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
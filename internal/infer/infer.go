@@ -0,0 +1,226 @@
+// Package infer provides reusable LLM-assisted inference for the metadata
+// that provider generators can't reliably read off an upstream API: a
+// friendly display name for a model, and whether a reasoning model exposes a
+// controllable reasoning effort.
+//
+// It was extracted out of cmd/apipie, which originally had its own
+// APIpie-specific copies of this logic. Generators that don't have (or
+// don't want to spend) an API key still get useful results: Analyzer runs in
+// offline mode whenever no usable backend is configured, falling back to
+// static heuristics instead of calling out to an LLM.
+//
+// The backend itself is pluggable (see LLMClient in llmclient.go): APIpie's
+// donated key remains the default so existing generators keep working
+// unmodified, but CATWALK_ENRICH_PROVIDER lets a contributor point Analyzer
+// at OpenAI, Anthropic, a local Ollama install, or a self-hosted Hugging
+// Face text-generation-inference endpoint instead.
+package infer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// ModelDescriptor is the generator-agnostic view of a model that Analyzer
+// needs in order to name it or classify its reasoning effort support.
+type ModelDescriptor struct {
+	ID               string
+	BaseModel        string
+	Provider         string
+	Subtype          string
+	InputModalities  []string
+	OutputModalities []string
+	ContextWindow    int64
+	Description      string
+}
+
+// Analyzer performs LLM-assisted inference against a pluggable LLMClient
+// backend (APIpie's donated chat-completions endpoint by default).
+type Analyzer struct {
+	client  LLMClient
+	offline bool
+}
+
+// NewAnalyzer builds an Analyzer. apiKey is used as the APIpie API key when
+// CATWALK_ENRICH_PROVIDER/CATWALK_ENRICH_API_KEY aren't set, preserving
+// existing call sites; see buildClientFromEnv for the full set of env vars
+// that select and configure the backend. An Analyzer with no usable
+// credentials for its backend runs in offline mode: every method falls back
+// to static heuristics instead of making network requests.
+func NewAnalyzer(apiKey string) *Analyzer {
+	client, offline := buildClientFromEnv(apiKey)
+	return &Analyzer{client: client, offline: offline}
+}
+
+// Offline reports whether the Analyzer has no usable backend configured and
+// will therefore only use static heuristics.
+func (a *Analyzer) Offline() bool {
+	return a.offline
+}
+
+// controllableReasoningIndicators are phrases in a model description that
+// suggest the model supports a configurable reasoning depth, used as the
+// offline fallback when no LLM call can be made (or it fails).
+var controllableReasoningIndicators = []string{
+	"thinking tokens", "reasoning budget", "controllable reasoning",
+	"thinking depth", "reasoning depth", "controllable depth",
+	"thinking budget", "reasoning effort", "configurable reasoning",
+	"adjustable reasoning",
+}
+
+// ReasoningEffort determines whether a model supports controllable
+// reasoning effort/depth based on its description. In offline mode (or if
+// the LLM call fails) it falls back to matching controllableReasoningIndicators.
+func (a *Analyzer) ReasoningEffort(ctx context.Context, description string) bool {
+	if description == "" {
+		return false
+	}
+
+	if !a.Offline() {
+		if result, ok := a.analyzeReasoningEffort(ctx, description); ok {
+			return result
+		}
+	}
+
+	desc := strings.ToLower(description)
+	for _, indicator := range controllableReasoningIndicators {
+		if strings.Contains(desc, indicator) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Analyzer) analyzeReasoningEffort(ctx context.Context, description string) (result, ok bool) {
+	prompt := fmt.Sprintf(`You are an AI model capability analyzer. Determine if this model supports controllable reasoning effort/depth.
+
+Look for indicators that users can control HOW MUCH reasoning the model does, such as:
+- Thinking token budgets/limits
+- Controllable reasoning depth
+- Adjustable thinking effort
+- Reasoning parameter control
+- Step-by-step thinking control
+- Configurable reasoning modes
+
+Description: "%s"
+
+Answer only "YES" if the model clearly supports controllable reasoning effort, or "NO" if it doesn't or if unclear.`, strings.Split(description, "\n")[0])
+
+	content, err := a.complete(ctx, prompt, 10)
+	if err != nil {
+		log.Printf("infer: reasoning effort analysis failed: %v", err)
+		return false, false
+	}
+
+	return strings.Contains(strings.ToUpper(content), "YES"), true
+}
+
+// DisplayNames generates display names for a group of models that share a
+// model ID, helping users differentiate between variants. It returns a map
+// keyed by the descriptor's ID. In offline mode it returns nil: callers
+// should fall back to their own naming (e.g. the model ID or another
+// offline-capable package such as internal/names).
+func (a *Analyzer) DisplayNames(ctx context.Context, models []ModelDescriptor) map[string]string {
+	if a.Offline() {
+		return nil
+	}
+
+	prompt := buildDisplayNamePrompt(models)
+	content, err := a.complete(ctx, prompt, 300)
+	if err != nil {
+		log.Printf("infer: display name generation failed: %v", err)
+		return nil
+	}
+
+	return parseDisplayNames(content, models)
+}
+
+func buildDisplayNamePrompt(models []ModelDescriptor) string {
+	var b strings.Builder
+	b.WriteString("You are a model naming expert. Generate professional display names for AI models that help users differentiate between variants.\n\nMODELS TO NAME:\n")
+
+	for i, m := range models {
+		inputMods := strings.Join(m.InputModalities, ", ")
+		if inputMods == "" {
+			inputMods = "text"
+		}
+		outputMods := strings.Join(m.OutputModalities, ", ")
+		if outputMods == "" {
+			outputMods = "text"
+		}
+
+		contextInfo := ""
+		switch {
+		case m.ContextWindow >= 1_000_000:
+			contextInfo = fmt.Sprintf("%dM tokens", m.ContextWindow/1_000_000)
+		case m.ContextWindow >= 1_000:
+			contextInfo = fmt.Sprintf("%dK tokens", m.ContextWindow/1_000)
+		case m.ContextWindow > 0:
+			contextInfo = fmt.Sprintf("%d tokens", m.ContextWindow)
+		}
+
+		fmt.Fprintf(&b, "[%d] Model ID: %q\n    Base Model: %q\n    Provider: %q\n    Subtype: %q\n    Input Modalities: %s\n    Output Modalities: %s\n    Context Window: %s\n    Description: %q\n\n",
+			i+1, m.ID, m.BaseModel, m.Provider, m.Subtype, inputMods, outputMods, contextInfo, strings.Split(m.Description, "\n")[0])
+	}
+
+	b.WriteString(`NAMING RULES:
+1. If one model has provider="pool", give it the simple canonical name (this is the meta-model)
+2. For provider-specific variants, add provider name: "GPT-4 (OpenAI)", "GPT-4 (Azure)"
+3. For multimodal variants, highlight capabilities: "GPT-4 Vision", "Claude 3.5 Sonnet (Vision)", "Gemini Pro (Audio)"
+4. For context window differences, include size when significant: "Claude 3.5 Sonnet (200K)", "GPT-4 Turbo (128K)"
+5. For feature variants, highlight differences: "GPT-4 Turbo", "Llama 3.1 Instruct", "Mistral 7B (Quantized)"
+6. Keep names under 50 characters
+7. Use proper capitalization and formatting
+8. Make differences clear and concise
+9. Prioritize: modalities > provider > context size > other features
+
+Generate names in this exact format (one per line):
+[1] -> Display Name Here
+[2] -> Display Name Here
+etc.`)
+
+	return b.String()
+}
+
+func parseDisplayNames(response string, models []ModelDescriptor) map[string]string {
+	result := make(map[string]string)
+
+	for _, line := range strings.Split(response, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.Contains(line, "] ->") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "] ->", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		indexStr := strings.TrimPrefix(strings.TrimSpace(parts[0]), "[")
+		name := strings.TrimSpace(parts[1])
+
+		idx, err := strconv.Atoi(indexStr)
+		if err != nil || idx < 1 || idx > len(models) {
+			continue
+		}
+
+		if len(name) > 0 && len(name) <= 60 && !strings.Contains(name, "\n") {
+			result[models[idx-1].ID] = name
+		}
+	}
+
+	return result
+}
+
+// complete sends a low-temperature completion request to the configured
+// LLMClient backend and returns its trimmed text response.
+func (a *Analyzer) complete(ctx context.Context, prompt string, maxTokens int) (string, error) {
+	content, err := a.client.Complete(ctx, "", prompt, CompleteOptions{MaxTokens: maxTokens, Temperature: 0.1})
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,27 @@
+package infer
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAnalyzerOfflineReasoningEffort(t *testing.T) {
+	a := NewAnalyzer("")
+	if !a.Offline() {
+		t.Fatal("expected analyzer with no API key to be offline")
+	}
+
+	if !a.ReasoningEffort(context.Background(), "Supports a configurable reasoning budget.") {
+		t.Error("expected offline heuristic to detect reasoning budget phrase")
+	}
+	if a.ReasoningEffort(context.Background(), "A general purpose chat model.") {
+		t.Error("expected offline heuristic to return false for unrelated description")
+	}
+}
+
+func TestAnalyzerOfflineDisplayNamesReturnsNil(t *testing.T) {
+	a := NewAnalyzer("")
+	if names := a.DisplayNames(context.Background(), []ModelDescriptor{{ID: "m1"}}); names != nil {
+		t.Errorf("expected nil display names in offline mode, got %v", names)
+	}
+}
@@ -0,0 +1,365 @@
+package infer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk/httpx"
+)
+
+// CompleteOptions controls a single LLMClient.Complete call.
+type CompleteOptions struct {
+	MaxTokens   int
+	Temperature float64
+}
+
+// LLMClient is a minimal chat-completion backend: given an optional system
+// prompt and a user prompt, it returns the model's text response. Analyzer
+// is the only caller; backends are free to drop or fold the system prompt
+// in whatever way fits their API.
+type LLMClient interface {
+	Complete(ctx context.Context, system, user string, opts CompleteOptions) (string, error)
+}
+
+// buildClientFromEnv selects and configures the LLMClient backend.
+// CATWALK_ENRICH_PROVIDER picks the backend ("apipie" (default), "openai",
+// "anthropic", "ollama", or "huggingface"); CATWALK_ENRICH_MODEL and
+// CATWALK_ENRICH_ENDPOINT override its model name and base URL;
+// CATWALK_ENRICH_API_KEY sets its credentials. legacyAPIKey is used as the
+// APIpie key when CATWALK_ENRICH_API_KEY isn't set, so the original
+// NewAnalyzer(apiKey) call sites keep working unmodified. It also reports
+// whether Analyzer should run offline, i.e. the selected backend has no
+// usable credentials.
+func buildClientFromEnv(legacyAPIKey string) (client LLMClient, offline bool) {
+	provider := os.Getenv("CATWALK_ENRICH_PROVIDER")
+	if provider == "" {
+		provider = "apipie"
+	}
+	model := os.Getenv("CATWALK_ENRICH_MODEL")
+	endpoint := os.Getenv("CATWALK_ENRICH_ENDPOINT")
+	apiKey := os.Getenv("CATWALK_ENRICH_API_KEY")
+	if apiKey == "" && provider == "apipie" {
+		apiKey = legacyAPIKey
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+
+	switch provider {
+	case "openai":
+		if model == "" {
+			model = "gpt-4o-mini"
+		}
+		if endpoint == "" {
+			endpoint = "https://api.openai.com/v1/chat/completions"
+		}
+		return &openAICompatibleClient{
+			endpoint: endpoint, model: model, apiKey: apiKey,
+			authHeader: "Authorization", authPrefix: "Bearer ",
+			httpClient: httpClient,
+		}, apiKey == ""
+	case "anthropic":
+		if model == "" {
+			model = "claude-sonnet-4-5"
+		}
+		if endpoint == "" {
+			endpoint = "https://api.anthropic.com/v1/messages"
+		}
+		return &anthropicClient{endpoint: endpoint, model: model, apiKey: apiKey, httpClient: httpClient}, apiKey == ""
+	case "ollama":
+		if model == "" {
+			model = "llama3.1"
+		}
+		if endpoint == "" {
+			endpoint = "http://localhost:11434/api/chat"
+		}
+		// Ollama is local and typically unauthenticated, so it's never
+		// considered offline purely for lack of an API key.
+		return &ollamaClient{endpoint: endpoint, model: model, httpClient: httpClient}, false
+	case "huggingface":
+		// Self-hosted text-generation-inference has no universal default
+		// URL; without one, treat the backend as offline.
+		return &huggingFaceClient{endpoint: endpoint, httpClient: httpClient}, endpoint == ""
+	default: // "apipie"
+		if model == "" {
+			model = "claude-sonnet-4-5"
+		}
+		if endpoint == "" {
+			endpoint = "https://apipie.ai/v1/chat/completions"
+		}
+		return &openAICompatibleClient{
+			endpoint: endpoint, model: model, apiKey: apiKey,
+			authHeader: "x-api-key", authPrefix: "",
+			httpClient: httpClient,
+		}, apiKey == ""
+	}
+}
+
+type chatRequest struct {
+	Messages    []chatMessage `json:"messages"`
+	Model       string        `json:"model"`
+	MaxTokens   int           `json:"max_tokens"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// openAICompatibleClient implements LLMClient against any OpenAI-style
+// /v1/chat/completions endpoint. OpenAI and APIpie both speak this shape;
+// they only differ in which header carries the API key, which authHeader
+// and authPrefix let a caller configure instead of duplicating the client.
+type openAICompatibleClient struct {
+	endpoint   string
+	model      string
+	apiKey     string
+	authHeader string
+	authPrefix string
+	httpClient *http.Client
+}
+
+func (c *openAICompatibleClient) Complete(ctx context.Context, system, user string, opts CompleteOptions) (string, error) {
+	var messages []chatMessage
+	if system != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: system})
+	}
+	messages = append(messages, chatMessage{Role: "user", Content: user})
+
+	jsonData, err := json.Marshal(chatRequest{
+		Messages:    messages,
+		Model:       c.model,
+		MaxTokens:   opts.MaxTokens,
+		Temperature: opts.Temperature,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling request: %w", err)
+	}
+
+	resp, _, err := httpx.Do(ctx, c.httpClient, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("building request: %w", err)
+		}
+		if c.apiKey != "" {
+			req.Header.Set(c.authHeader, c.authPrefix+c.apiKey)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, httpx.DefaultPolicy)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var parsed chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return "", fmt.Errorf("empty choices in response")
+	}
+
+	return strings.TrimSpace(parsed.Choices[0].Message.Content), nil
+}
+
+// anthropicClient implements LLMClient against the Anthropic Messages API.
+type anthropicClient struct {
+	endpoint   string
+	model      string
+	apiKey     string
+	httpClient *http.Client
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+func (c *anthropicClient) Complete(ctx context.Context, system, user string, opts CompleteOptions) (string, error) {
+	jsonData, err := json.Marshal(anthropicRequest{
+		Model:     c.model,
+		MaxTokens: opts.MaxTokens,
+		System:    system,
+		Messages:  []anthropicMessage{{Role: "user", Content: user}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling request: %w", err)
+	}
+
+	resp, _, err := httpx.Do(ctx, c.httpClient, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("building request: %w", err)
+		}
+		req.Header.Set("x-api-key", c.apiKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, httpx.DefaultPolicy)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var parsed anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+	for _, block := range parsed.Content {
+		if block.Type == "text" {
+			return strings.TrimSpace(block.Text), nil
+		}
+	}
+	return "", fmt.Errorf("no text content in response")
+}
+
+// ollamaClient implements LLMClient against a local Ollama install's
+// /api/chat endpoint.
+type ollamaClient struct {
+	endpoint   string
+	model      string
+	httpClient *http.Client
+}
+
+type ollamaRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+}
+
+type ollamaResponse struct {
+	Message chatMessage `json:"message"`
+}
+
+func (c *ollamaClient) Complete(ctx context.Context, system, user string, _ CompleteOptions) (string, error) {
+	var messages []chatMessage
+	if system != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: system})
+	}
+	messages = append(messages, chatMessage{Role: "user", Content: user})
+
+	jsonData, err := json.Marshal(ollamaRequest{Model: c.model, Messages: messages, Stream: false})
+	if err != nil {
+		return "", fmt.Errorf("marshaling request: %w", err)
+	}
+
+	resp, _, err := httpx.Do(ctx, c.httpClient, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("building request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, httpx.DefaultPolicy)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var parsed ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+	return strings.TrimSpace(parsed.Message.Content), nil
+}
+
+// huggingFaceClient implements LLMClient against a self-hosted Hugging Face
+// text-generation-inference server's /generate endpoint. TGI has no notion
+// of separate system/user turns, so the two are folded into one prompt.
+type huggingFaceClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+type hfGenerateRequest struct {
+	Inputs     string           `json:"inputs"`
+	Parameters hfGenerateParams `json:"parameters"`
+}
+
+type hfGenerateParams struct {
+	MaxNewTokens int     `json:"max_new_tokens"`
+	Temperature  float64 `json:"temperature"`
+}
+
+type hfGenerateResponse struct {
+	GeneratedText string `json:"generated_text"`
+}
+
+func (c *huggingFaceClient) Complete(ctx context.Context, system, user string, opts CompleteOptions) (string, error) {
+	if c.endpoint == "" {
+		return "", fmt.Errorf("huggingface backend: CATWALK_ENRICH_ENDPOINT is required (a text-generation-inference URL)")
+	}
+
+	prompt := user
+	if system != "" {
+		prompt = system + "\n\n" + user
+	}
+
+	jsonData, err := json.Marshal(hfGenerateRequest{
+		Inputs:     prompt,
+		Parameters: hfGenerateParams{MaxNewTokens: opts.MaxTokens, Temperature: opts.Temperature},
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling request: %w", err)
+	}
+
+	resp, _, err := httpx.Do(ctx, c.httpClient, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("building request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, httpx.DefaultPolicy)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading response: %w", err)
+	}
+
+	// TGI's /generate returns a single object normally, or an array when
+	// called with best_of/batched parameters; decode flexibly.
+	var single hfGenerateResponse
+	if err := json.Unmarshal(body, &single); err == nil && single.GeneratedText != "" {
+		return strings.TrimSpace(single.GeneratedText), nil
+	}
+	var list []hfGenerateResponse
+	if err := json.Unmarshal(body, &list); err == nil && len(list) > 0 {
+		return strings.TrimSpace(list[0].GeneratedText), nil
+	}
+	return "", fmt.Errorf("unrecognized response shape")
+}
@@ -0,0 +1,125 @@
+package names
+
+import "strings"
+
+// canonicalAliases maps a known model ID — bare or provider-prefixed — to a
+// canonical ID of the form "<vendor>:<family-model>". Unlike modelNames,
+// which maps many IDs to one display string, this table exists so multiple
+// IDs that name the very same underlying model (across providers, or across
+// a provider's own "bare" vs. "provider/bare" forms) resolve to one value a
+// caller can group or deduplicate on.
+var canonicalAliases = map[string]string{
+	// Anthropic
+	"claude-sonnet-4-5":           "anthropic:claude-sonnet-4.5",
+	"claude-sonnet-4-5-20250929":  "anthropic:claude-sonnet-4.5",
+	"claude-sonnet-4-5-20250214":  "anthropic:claude-sonnet-4.5",
+	"anthropic/claude-sonnet-4.5": "anthropic:claude-sonnet-4.5",
+	"claude-sonnet-4":             "anthropic:claude-sonnet-4",
+	"claude-sonnet-4-0":           "anthropic:claude-sonnet-4",
+	"anthropic/claude-sonnet-4":   "anthropic:claude-sonnet-4",
+	"claude-opus-4-5":             "anthropic:claude-opus-4.5",
+	"claude-opus-4-5-20251101":    "anthropic:claude-opus-4.5",
+	"claude-opus-4":               "anthropic:claude-opus-4",
+	"claude-opus-4-0":             "anthropic:claude-opus-4",
+	"claude-opus-4-1":             "anthropic:claude-opus-4.1",
+	"claude-3-opus-20240229":      "anthropic:claude-3-opus",
+	"anthropic/claude-3-opus":     "anthropic:claude-3-opus",
+	"claude-3-5-haiku-20241022":   "anthropic:claude-3.5-haiku",
+	"claude-3-5-haiku":            "anthropic:claude-3.5-haiku",
+	"anthropic/claude-3.5-haiku":  "anthropic:claude-3.5-haiku",
+	"claude-3-haiku-20240307":     "anthropic:claude-3-haiku",
+	"anthropic/claude-3-haiku":    "anthropic:claude-3-haiku",
+	"claude-3-5-sonnet-20241022":  "anthropic:claude-3.5-sonnet",
+	"claude-3-5-sonnet":           "anthropic:claude-3.5-sonnet",
+	"claude-3-7-sonnet":           "anthropic:claude-3.7-sonnet",
+
+	// OpenAI
+	"gpt-5":                      "openai:gpt-5",
+	"openai/gpt-5":               "openai:gpt-5",
+	"gpt-5.2":                    "openai:gpt-5.2",
+	"openai/gpt-5.2":             "openai:gpt-5.2",
+	"gpt-5.2-codex":              "openai:gpt-5.2-codex",
+	"gpt-5.1":                    "openai:gpt-5.1",
+	"gpt-5.1-codex":              "openai:gpt-5.1-codex",
+	"gpt-4.1":                    "openai:gpt-4.1",
+	"gpt-4o":                     "openai:gpt-4o",
+	"gpt-4o-2024-11-20":          "openai:gpt-4o",
+	"gpt-4-turbo":                "openai:gpt-4-turbo",
+	"openai/gpt-4-turbo":         "openai:gpt-4-turbo",
+	"gpt-4-turbo-preview":        "openai:gpt-4-turbo-preview",
+	"openai/gpt-4-turbo-preview": "openai:gpt-4-turbo-preview",
+	"gpt-3.5-turbo":              "openai:gpt-3.5-turbo",
+	"openai/gpt-3.5-turbo":       "openai:gpt-3.5-turbo",
+
+	// Google/Gemini
+	"gemini-2.5-pro":          "google:gemini-2.5-pro",
+	"gemini-2.5-flash":        "google:gemini-2.5-flash",
+	"gemini-1.5-pro":          "google:gemini-1.5-pro",
+	"google/gemini-pro-1.5":   "google:gemini-1.5-pro",
+	"gemini-1.5-flash":        "google:gemini-1.5-flash",
+	"google/gemini-flash-1.5": "google:gemini-1.5-flash",
+
+	// Meta (Llama)
+	"llama-3.3-70b-instruct":             "meta:llama-3.3-70b",
+	"meta-llama/llama-3.3-70b-instruct":  "meta:llama-3.3-70b",
+	"llama-3.2-3b-instruct":              "meta:llama-3.2-3b",
+	"meta-llama/llama-3.2-3b-instruct":   "meta:llama-3.2-3b",
+	"llama-3.1-405b-instruct":            "meta:llama-3.1-405b",
+	"meta-llama/llama-3.1-405b-instruct": "meta:llama-3.1-405b",
+
+	// Mistral
+	"mistral-large-2411":       "mistral:mistral-large",
+	"mistralai/mistral-large":  "mistral:mistral-large",
+	"mistral-medium-2312":      "mistral:mistral-medium",
+	"mistralai/mistral-medium": "mistral:mistral-medium",
+	"mistral-small-2402":       "mistral:mistral-small",
+	"mistralai/mistral-small":  "mistral:mistral-small",
+
+	// Alibaba (Qwen)
+	"qwen-2.5-72b-instruct":      "qwen:qwen-2.5-72b",
+	"qwen/qwen-2.5-72b-instruct": "qwen:qwen-2.5-72b",
+}
+
+// CanonicalID returns the canonical ID for modelID, e.g.
+// "anthropic:claude-sonnet-4.5", so callers aggregating models across
+// multiple providers can tell that two provider-specific IDs name the same
+// underlying model. It checks canonicalAliases first (exact, lowercased,
+// then with any "provider/" prefix stripped), and falls back to the same
+// token-set matchScore matcher GetDisplayName uses for IDs not yet in the
+// table (e.g. "openrouter/anthropic/claude-sonnet-4.5:beta"). It returns ""
+// if nothing is close enough to be a reliable match.
+func CanonicalID(modelID string) string {
+	if id, ok := lookupCanonicalAlias(modelID); ok {
+		return id
+	}
+	return canonicalFromFuzzyMatch(modelID)
+}
+
+func lookupCanonicalAlias(modelID string) (string, bool) {
+	if id, ok := canonicalAliases[modelID]; ok {
+		return id, true
+	}
+
+	lowered := strings.ToLower(modelID)
+	if id, ok := canonicalAliases[lowered]; ok {
+		return id, true
+	}
+
+	if idx := strings.LastIndex(modelID, "/"); idx != -1 {
+		baseModel := modelID[idx+1:]
+		if id, ok := canonicalAliases[baseModel]; ok {
+			return id, true
+		}
+		if id, ok := canonicalAliases[strings.ToLower(baseModel)]; ok {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// canonicalFromFuzzyMatch runs modelID through the same bestMatch scorer
+// findBestMatch uses, against canonicalAliases instead of modelNames.
+func canonicalFromFuzzyMatch(modelID string) string {
+	return bestMatch(modelID, canonicalAliases)
+}
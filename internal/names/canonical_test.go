@@ -0,0 +1,36 @@
+package names
+
+import "testing"
+
+func TestCanonicalIDFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		modelID string
+		want    string
+	}{
+		{
+			name:    "a provider-qualified ID not in canonicalAliases falls back to the fuzzy matcher",
+			modelID: "openrouter/anthropic/claude-sonnet-4.5:beta",
+			want:    "anthropic:claude-sonnet-4.5",
+		},
+		{
+			name:    "a date-suffixed snapshot not in canonicalAliases still resolves",
+			modelID: "mistral-large-2411-preview",
+			want:    "mistral:mistral-large",
+		},
+		{
+			name:    "an unrecognized model falls through to empty",
+			modelID: "totally-unknown-xyz",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CanonicalID(tt.modelID)
+			if got != tt.want {
+				t.Errorf("CanonicalID(%q) = %q, want %q", tt.modelID, got, tt.want)
+			}
+		})
+	}
+}
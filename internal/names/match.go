@@ -0,0 +1,219 @@
+package names
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// nonNumericWeight is how much weight the non-numeric token-set Jaccard
+	// similarity carries in matchScore. Set equal to matchScoreThreshold so
+	// an exact non-numeric token match alone clears it even when neither ID
+	// names a recognized modelFamilies vendor (e.g. DeepSeek, GLM, Qwen, or
+	// an as-yet-unlisted one like Phi): family recognition sharpens
+	// disambiguation, it isn't a requirement to match at all.
+	nonNumericWeight = 0.75
+	// numericWeight is how much weight the numeric ("version") token-set
+	// Jaccard similarity carries in matchScore. Kept separate from, and far
+	// smaller than, nonNumericWeight so two IDs naming different versions of
+	// the same family ("claude-sonnet-4" vs "claude-sonnet-4-5") don't tie
+	// just because their non-numeric tokens are identical, while still
+	// staying small enough that an unrelated model sharing a version number
+	// ("gpt-4o" vs "gpt-4") can't ride numeric overlap past the threshold.
+	numericWeight = 0.1
+	// familyMatchBonus rewards two IDs sharing a known model family token
+	// (e.g. both "claude").
+	familyMatchBonus = 0.2
+	// familyMismatchPenalty punishes two IDs naming different known
+	// families outright (e.g. "claude" vs "gpt"), so a coincidental token
+	// overlap elsewhere can't out-vote an obvious family mismatch.
+	familyMismatchPenalty = 0.5
+
+	// matchScoreThreshold is the minimum matchScore findBestMatch requires
+	// before it trusts a candidate.
+	matchScoreThreshold = 0.75
+	// matchScoreEpsilon is how close two candidates' scores can be before
+	// findBestMatch treats the result as ambiguous and refuses to guess.
+	matchScoreEpsilon = 0.02
+)
+
+// modelFamilies lists the vendor/family tokens findBestMatch treats
+// specially: an exact match between two IDs is a strong positive signal,
+// and a mismatch (e.g. "claude" vs "gpt") is a strong negative one. A
+// family going unrecognized here isn't fatal to matching it (see
+// nonNumericWeight), but listing it still sharpens disambiguation between
+// two otherwise-similar token sets.
+var modelFamilies = map[string]bool{
+	"claude":   true,
+	"gpt":      true,
+	"gemini":   true,
+	"llama":    true,
+	"deepseek": true,
+	"glm":      true,
+	"mistral":  true,
+	"mixtral":  true,
+	"qwen":     true,
+	"minimax":  true,
+	"grok":     true,
+	"kimi":     true,
+	"command":  true,
+}
+
+var tokenizeRegexp = regexp.MustCompile(`[0-9]+|[a-zA-Z]+`)
+
+// tokenize splits a model ID into its alphabetic and numeric runs on digit/
+// letter boundaries (as well as the `-`, `/`, `_`, and `.` separators, which
+// simply aren't part of either character class), so
+// "claude-3-5-sonnet-20241022" becomes
+// ["claude", "3", "5", "sonnet", "20241022"].
+func tokenize(modelID string) []string {
+	return tokenizeRegexp.FindAllString(strings.ToLower(modelID), -1)
+}
+
+func isNumeric(token string) bool {
+	if token == "" {
+		return false
+	}
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func filterNonNumeric(tokens []string) []string {
+	var out []string
+	for _, t := range tokens {
+		if !isNumeric(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func filterNumeric(tokens []string) []string {
+	var out []string
+	for _, t := range tokens {
+		if isNumeric(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// familyToken returns the first token in tokens that's a known model
+// family, or "" if none is present.
+func familyToken(tokens []string) string {
+	for _, t := range tokens {
+		if modelFamilies[t] {
+			return t
+		}
+	}
+	return ""
+}
+
+func tokenSet(tokens []string) map[string]bool {
+	set := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		set[t] = true
+	}
+	return set
+}
+
+// jaccardIndex returns the Jaccard similarity of two token sets: the size
+// of their intersection over the size of their union.
+func jaccardIndex(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for t := range a {
+		if b[t] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+// matchScore scores how likely candidate is to name the same model as
+// target: a weighted Jaccard similarity over their non-numeric tokens, plus
+// a separately-weighted Jaccard similarity over their numeric ("version")
+// tokens, plus a bonus when they share a known family token (and a penalty
+// when they name two different ones).
+//
+// Scoring the numeric tokens on their own, rather than only as a bonus
+// gated on an exact non-numeric match, is what tells "claude-sonnet-4" and
+// "claude-sonnet-4-5" apart even when something else in the target ID (an
+// extra "-beta" suffix, say) already keeps their non-numeric Jaccard
+// similarity below 1: a fully-overlapping version number still outscores a
+// partially-overlapping one.
+//
+// Non-numeric tokens carry more weight than numeric ones so a version bump
+// alone can't out-vote an actual family mismatch: "gpt-4o" and "gpt-4"
+// share the "gpt" family, but "4o" contributes an extra non-numeric token
+// "o" that "4" doesn't have, so their non-numeric Jaccard similarity stays
+// well under 1 regardless of how their numeric tokens compare.
+func matchScore(target, candidate []string) float64 {
+	nonNumJaccard := jaccardIndex(tokenSet(filterNonNumeric(target)), tokenSet(filterNonNumeric(candidate)))
+	numJaccard := jaccardIndex(tokenSet(filterNumeric(target)), tokenSet(filterNumeric(candidate)))
+
+	score := nonNumJaccard*nonNumericWeight + numJaccard*numericWeight
+
+	famTarget := familyToken(target)
+	famCandidate := familyToken(candidate)
+	switch {
+	case famTarget != "" && famCandidate != "" && famTarget == famCandidate:
+		score += familyMatchBonus
+	case famTarget != "" && famCandidate != "" && famTarget != famCandidate:
+		score -= familyMismatchPenalty
+	}
+
+	return score
+}
+
+// bestMatch tokenizes modelID and every key of candidates and scores each
+// with matchScore, returning the value mapped to the best-scoring key. It's
+// shared by findBestMatch (over modelNames) and canonicalFromFuzzyMatch
+// (over canonicalAliases) so both fuzzy-match fallbacks use the same
+// token-set similarity and the same ambiguity rules. It returns "" unless
+// the best candidate clears matchScoreThreshold and no other candidate
+// mapped to a *different* value ties within matchScoreEpsilon.
+func bestMatch(modelID string, candidates map[string]string) string {
+	target := tokenize(modelID)
+
+	var bestValue string
+	bestScore := 0.0
+	for knownID, value := range candidates {
+		if score := matchScore(target, tokenize(knownID)); score > bestScore {
+			bestScore = score
+			bestValue = value
+		}
+	}
+	if bestScore < matchScoreThreshold {
+		return ""
+	}
+
+	// A second pass for the best-scoring candidate with a *different* value
+	// than bestValue - two known IDs mapping to the same value (e.g. two
+	// date-suffixed snapshots of "Claude Sonnet 4.5") shouldn't count as an
+	// ambiguous result.
+	secondScore := 0.0
+	for knownID, value := range candidates {
+		if value == bestValue {
+			continue
+		}
+		if score := matchScore(target, tokenize(knownID)); score > secondScore {
+			secondScore = score
+		}
+	}
+	if bestScore-secondScore < matchScoreEpsilon {
+		return ""
+	}
+
+	return bestValue
+}
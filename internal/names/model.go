@@ -1,6 +1,6 @@
 // Package names provides utilities for generating human-readable model names
-// from model IDs. It uses a combination of static mappings and Levenshtein
-// distance-based fuzzy matching to provide consistent, user-friendly names.
+// from model IDs. It uses a combination of static mappings and token-set
+// fuzzy matching (see matchScore) to provide consistent, user-friendly names.
 package names
 
 import (
@@ -340,57 +340,13 @@ func capitalizeWord(word string) string {
 	return strings.ToUpper(word[0:1]) + word[1:]
 }
 
-const fuzzyMatchThreshold = 2 // Maximum edit distance to consider for fuzzy matching
-
-// findBestMatch uses Levenshtein distance to find the best matching model name.
+// findBestMatch tokenizes modelID and every known model ID (splitting on
+// `-`, `/`, `_`, and digit/letter boundaries) and scores each with
+// matchScore, a token-set similarity that's resilient to version-number
+// suffixes without false-matching IDs that merely share a family prefix
+// (see matchScore's doc comment). It returns "" - falling through to
+// formatModelName - unless the best candidate clears matchScoreThreshold
+// and no other candidate ties within matchScoreEpsilon.
 func findBestMatch(modelID string) string {
-	var bestMatch string
-	minDistance := fuzzyMatchThreshold + 1
-
-	for knownID, name := range modelNames {
-		distance := levenshteinDistance(modelID, knownID)
-		if distance < minDistance {
-			minDistance = distance
-			bestMatch = name
-		}
-	}
-
-	return bestMatch
-}
-
-// levenshteinDistance computes the edit distance between two strings.
-func levenshteinDistance(a, b string) int {
-	switch {
-	case len(a) == 0:
-		return len(b)
-	case len(b) == 0:
-		return len(a)
-	}
-
-	previous := make([]int, len(b)+1)
-	for j := range previous {
-		previous[j] = j
-	}
-
-	for i := 1; i <= len(a); i++ {
-		current := make([]int, len(b)+1)
-		current[0] = i
-
-		for j := 1; j <= len(b); j++ {
-			cost := 0
-			if a[i-1] != b[j-1] {
-				cost = 1
-			}
-
-			deletion := previous[j] + 1
-			insertion := current[j-1] + 1
-			substitution := previous[j-1] + cost
-
-			current[j] = min(deletion, min(insertion, substitution))
-		}
-
-		previous = current
-	}
-
-	return previous[len(b)]
+	return bestMatch(modelID, modelNames)
 }
@@ -0,0 +1,59 @@
+package names
+
+import "testing"
+
+func TestGetDisplayNameFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		modelID string
+		want    string
+	}{
+		{
+			name:    "date-suffixed snapshot of a known model still matches",
+			modelID: "claude-3-5-sonnet-20251231",
+			want:    "Claude 3.5 Sonnet",
+		},
+		{
+			name:    "gpt-4o is not confused with gpt-4 despite sharing a family and version token",
+			modelID: "gpt-4o-unknown-snapshot",
+			want:    "",
+		},
+		{
+			name:    "a different known family entirely falls through",
+			modelID: "gpt-4-some-future-snapshot",
+			want:    "",
+		},
+		{
+			name:    "a date-suffixed DeepSeek variant still matches its known family token",
+			modelID: "deepseek-r1-20250301",
+			want:    "DeepSeek R1",
+		},
+		{
+			name:    "a vendor outside modelFamilies still matches on an exact non-numeric token set",
+			modelID: "phi-4-2025",
+			want:    "Phi 4",
+		},
+		{
+			name:    "the provider-qualified real-world ID from the canonical ID request resolves",
+			modelID: "anthropic/claude-sonnet-4.5:beta",
+			want:    "Claude Sonnet 4.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findBestMatch(tt.modelID)
+			if got != tt.want {
+				t.Errorf("findBestMatch(%q) = %q, want %q", tt.modelID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDisplayNameFallsBackToFormatting(t *testing.T) {
+	got := GetDisplayName("totally-unknown-model-xyz-123")
+	want := "Totally Unknown Model Xyz 123"
+	if got != want {
+		t.Errorf("GetDisplayName(unknown) = %q, want %q", got, want)
+	}
+}
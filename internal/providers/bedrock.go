@@ -0,0 +1,55 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/providers/bedrock"
+)
+
+// bedrockProvider returns the static, checked-in Bedrock provider config.
+func bedrockProvider() catwalk.Provider {
+	for _, p := range GetAll() {
+		if p.ID == catwalk.InferenceProviderBedrock {
+			return p
+		}
+	}
+	return catwalk.Provider{ID: catwalk.InferenceProviderBedrock}
+}
+
+// bedrockProviderWithDiscovery returns bedrockProvider's static baseline
+// with its model list augmented by bedrock.BedrockDiscoverer: a model AWS
+// exposes through ListFoundationModels/GetFoundationModel replaces or adds
+// to the checked-in entry with the same ID, so a new Nova/Claude/Llama
+// revision shows up without waiting for a catwalk release. If discovery
+// fails, the static baseline is returned as-is (with the error) so a
+// degraded or offline AWS client doesn't break callers that only care about
+// having something to serve.
+func bedrockProviderWithDiscovery(ctx context.Context, client bedrock.BedrockAPI, opts ...bedrock.Option) (catwalk.Provider, error) {
+	provider := bedrockProvider()
+
+	discovered, err := bedrock.NewDiscoverer(client, opts...).Discover(ctx)
+	if err != nil {
+		return provider, fmt.Errorf("bedrock: discovering models: %w", err)
+	}
+
+	byID := make(map[string]catwalk.Model, len(provider.Models)+len(discovered))
+	for _, m := range provider.Models {
+		byID[m.ID] = m
+	}
+	for _, m := range discovered {
+		byID[m.ID] = m
+	}
+
+	models := make([]catwalk.Model, 0, len(byID))
+	for _, m := range byID {
+		models = append(models, m)
+	}
+	slices.SortFunc(models, func(a, b catwalk.Model) int { return strings.Compare(a.ID, b.ID) })
+
+	provider.Models = models
+	return provider, nil
+}
@@ -0,0 +1,32 @@
+package providers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compress returns the gzip and zstd encodings of data. publish calls this
+// once per snapshot so serving a request is just picking the right
+// precomputed byte slice based on Accept-Encoding, instead of compressing
+// on every request.
+func compress(data []byte) (gz, zst []byte, err error) {
+	var gzBuf bytes.Buffer
+	gw := gzip.NewWriter(&gzBuf)
+	if _, err := gw.Write(data); err != nil {
+		return nil, nil, fmt.Errorf("gzip compressing providers: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, nil, fmt.Errorf("gzip compressing providers: %w", err)
+	}
+
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building zstd encoder: %w", err)
+	}
+	defer enc.Close()
+
+	return gzBuf.Bytes(), enc.EncodeAll(data, nil), nil
+}
@@ -0,0 +1,142 @@
+package generators
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/fetcher"
+)
+
+func init() { fetcher.Register(AIHubMixSource{}) }
+
+// aiHubMixMinContextWindow is the smallest context window worth surfacing;
+// smaller models are dropped in Normalize.
+const aiHubMixMinContextWindow = 20000
+
+// aiHubMixModel represents a model from the AIHubMix API.
+type aiHubMixModel struct {
+	ModelID         string          `json:"model_id"`
+	Desc            string          `json:"desc"`
+	Pricing         aiHubMixPricing `json:"pricing"`
+	Types           string          `json:"types"`
+	Features        string          `json:"features"`
+	InputModalities string          `json:"input_modalities"`
+	MaxOutput       int64           `json:"max_output"`
+	ContextLength   int64           `json:"context_length"`
+}
+
+// aiHubMixPricing contains the pricing information from the API.
+type aiHubMixPricing struct {
+	Input      *float64 `json:"input"`
+	Output     *float64 `json:"output"`
+	CacheRead  *float64 `json:"cache_read"`
+	CacheWrite *float64 `json:"cache_write"`
+}
+
+// aiHubMixModelsResponse is the response structure for the models API.
+type aiHubMixModelsResponse struct {
+	Data    []aiHubMixModel `json:"data"`
+	Message string          `json:"message"`
+	Success bool            `json:"success"`
+}
+
+func aiHubMixHasFeature(features, feature string) bool {
+	if features == "" {
+		return false
+	}
+	for f := range strings.SplitSeq(features, ",") {
+		if strings.TrimSpace(f) == feature {
+			return true
+		}
+	}
+	return false
+}
+
+func aiHubMixHasModality(modalities, modality string) bool {
+	if modalities == "" {
+		return false
+	}
+	for m := range strings.SplitSeq(modalities, ",") {
+		if strings.TrimSpace(m) == modality {
+			return true
+		}
+	}
+	return false
+}
+
+func aiHubMixParseFloat(p *float64) float64 {
+	if p == nil {
+		return 0.0
+	}
+	return *p
+}
+
+// AIHubMixSource adapts the AIHubMix API to fetcher.Source.
+type AIHubMixSource struct{}
+
+func (AIHubMixSource) ProviderID() catwalk.InferenceProvider { return catwalk.InferenceAIHubMix }
+
+func (AIHubMixSource) Provider() catwalk.Provider {
+	return catwalk.Provider{
+		Name:                "AIHubMix",
+		ID:                  catwalk.InferenceAIHubMix,
+		APIKey:              "$AIHUBMIX_API_KEY",
+		APIEndpoint:         "https://aihubmix.com/v1",
+		Type:                catwalk.TypeOpenAICompat,
+		DefaultLargeModelID: "gpt-5",
+		DefaultSmallModelID: "gpt-5-nano",
+		DefaultHeaders: map[string]string{
+			"APP-Code": "IUFF7106",
+		},
+	}
+}
+
+func (AIHubMixSource) Endpoint() string { return "https://aihubmix.com/api/v1/models?type=llm" }
+
+func (AIHubMixSource) Decode(body io.Reader) ([]fetcher.RawModel, error) {
+	var mr aiHubMixModelsResponse
+	if err := json.NewDecoder(body).Decode(&mr); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	raw := make([]fetcher.RawModel, len(mr.Data))
+	for i, m := range mr.Data {
+		raw[i] = fetcher.RawModel{ID: m.ModelID, Data: m}
+	}
+	return raw, nil
+}
+
+func (AIHubMixSource) Normalize(r fetcher.RawModel) (catwalk.Model, bool) {
+	model := r.Data.(aiHubMixModel) //nolint:forcetypeassert
+
+	if !fetcher.MinContextWindow(model.ContextLength, aiHubMixMinContextWindow) {
+		return catwalk.Model{}, false
+	}
+	if !aiHubMixHasModality(model.InputModalities, "text") {
+		return catwalk.Model{}, false
+	}
+
+	canReason := aiHubMixHasFeature(model.Features, "thinking")
+	levels, defaultReasoning := fetcher.ReasoningDefaults(canReason)
+
+	defaultMaxTokens := model.MaxOutput
+	if defaultMaxTokens == 0 || defaultMaxTokens > model.ContextLength/2 {
+		defaultMaxTokens = model.ContextLength / 10
+	}
+
+	return catwalk.Model{
+		ID:                     model.ModelID,
+		Name:                   model.ModelID,
+		CostPer1MIn:            aiHubMixParseFloat(model.Pricing.Input),
+		CostPer1MOut:           aiHubMixParseFloat(model.Pricing.Output),
+		CostPer1MInCached:      aiHubMixParseFloat(model.Pricing.CacheRead),
+		CostPer1MOutCached:     aiHubMixParseFloat(model.Pricing.CacheWrite),
+		ContextWindow:          model.ContextLength,
+		DefaultMaxTokens:       defaultMaxTokens,
+		CanReason:              canReason,
+		ReasoningLevels:        levels,
+		DefaultReasoningEffort: defaultReasoning,
+		SupportsImages:         aiHubMixHasModality(model.InputModalities, "image"),
+	}, true
+}
@@ -0,0 +1,237 @@
+package generators
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/fetcher"
+)
+
+func init() { fetcher.Register(CopilotSource{}) }
+
+// copilotResponse is the response from Copilot's /models endpoint.
+type copilotResponse struct {
+	Object string         `json:"object"`
+	Data   []copilotModel `json:"data"`
+}
+
+// copilotModel represents a model from the Copilot API.
+type copilotModel struct {
+	ID                 string            `json:"id"`
+	Name               string            `json:"name"`
+	Version            string            `json:"version"`
+	Vendor             string            `json:"vendor"`
+	Preview            bool              `json:"preview"`
+	ModelPickerEnabled bool              `json:"model_picker_enabled"`
+	Capabilities       copilotCapability `json:"capabilities"`
+	Policy             *copilotPolicy    `json:"policy,omitempty"`
+}
+
+// copilotCapability describes a Copilot model's tokenizer, limits and
+// supported features.
+type copilotCapability struct {
+	Family    string          `json:"family"`
+	Type      string          `json:"type"`
+	Tokenizer string          `json:"tokenizer"`
+	Limits    copilotLimits   `json:"limits"`
+	Supports  copilotSupports `json:"supports"`
+}
+
+// copilotLimits holds a Copilot model's context/output/prompt token caps.
+type copilotLimits struct {
+	MaxContextWindowTokens int `json:"max_context_window_tokens,omitempty"`
+	MaxOutputTokens        int `json:"max_output_tokens,omitempty"`
+	MaxPromptTokens        int `json:"max_prompt_tokens,omitempty"`
+}
+
+// copilotSupports describes the tool-calling/thinking features a Copilot
+// model supports.
+type copilotSupports struct {
+	ToolCalls         bool `json:"tool_calls,omitempty"`
+	ParallelToolCalls bool `json:"parallel_tool_calls,omitempty"`
+	MaxThinkingBudget int  `json:"max_thinking_budget,omitempty"`
+	MinThinkingBudget int  `json:"min_thinking_budget,omitempty"`
+}
+
+// copilotPolicy is the usage policy Copilot attaches to some models.
+type copilotPolicy struct {
+	State string `json:"state"`
+	Terms string `json:"terms"`
+}
+
+var copilotVersionedModelRegexp = regexp.MustCompile(`-\d{4}-\d{2}-\d{2}$`)
+
+// CopilotSource adapts Copilot's /models endpoint to fetcher.Source.
+type CopilotSource struct{}
+
+func (CopilotSource) ProviderID() catwalk.InferenceProvider { return catwalk.InferenceProviderCopilot }
+
+func (CopilotSource) Endpoint() string { return "https://api.githubcopilot.com/models" }
+
+func (CopilotSource) Provider() catwalk.Provider {
+	return catwalk.Provider{
+		ID:          catwalk.InferenceProviderCopilot,
+		Name:        "GitHub Copilot",
+		APIEndpoint: "https://api.githubcopilot.com",
+		Type:        catwalk.TypeOpenAICompat,
+	}
+}
+
+func (CopilotSource) Headers() map[string]string {
+	return map[string]string{
+		"Accept":        "application/json",
+		"Authorization": "Bearer " + copilotToken(),
+	}
+}
+
+func (CopilotSource) Decode(body io.Reader) ([]fetcher.RawModel, error) {
+	var resp copilotResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	// NOTE(@andreynering): Exclude versioned models and keep only the main version of each.
+	models := slices.DeleteFunc(resp.Data, func(m copilotModel) bool {
+		return m.ID != m.Version || copilotVersionedModelRegexp.MatchString(m.ID) || strings.Contains(m.ID, "embedding")
+	})
+
+	raw := make([]fetcher.RawModel, 0, len(models))
+	for _, m := range models {
+		raw = append(raw, fetcher.RawModel{ID: m.ID, Data: m})
+	}
+	return raw, nil
+}
+
+func (CopilotSource) Normalize(r fetcher.RawModel) (catwalk.Model, bool) {
+	m := r.Data.(copilotModel) //nolint:forcetypeassert
+
+	canReason, reasoningLevels, defaultReasoning := copilotReasoningCapabilities(m)
+	return catwalk.Model{
+		ID:                     m.ID,
+		Name:                   m.Name,
+		DefaultMaxTokens:       int64(m.Capabilities.Limits.MaxOutputTokens),
+		ContextWindow:          int64(m.Capabilities.Limits.MaxContextWindowTokens),
+		CanReason:              canReason,
+		ReasoningLevels:        reasoningLevels,
+		DefaultReasoningEffort: defaultReasoning,
+		SupportsImages:         copilotAttachmentSupport(m),
+	}, true
+}
+
+func (CopilotSource) PickDefaults(models []catwalk.Model) (large, small string) {
+	return fetcher.ByTag(
+		func(m catwalk.Model) bool { return m.ID == "claude-sonnet-4.5" },
+		func(m catwalk.Model) bool { return m.ID == "claude-haiku-4.5" },
+	)(models)
+}
+
+func copilotReasoningCapabilities(m copilotModel) (canReason bool, levels []string, defaultLevel string) {
+	// Claude models with reasoning support
+	if m.ID == "claude-3.7-sonnet" ||
+		m.ID == "claude-haiku-4.5" ||
+		m.ID == "claude-opus-4.5" ||
+		m.ID == "claude-sonnet-4" ||
+		m.ID == "claude-sonnet-4.5" {
+		return true, nil, ""
+	}
+
+	// Gemini models with reasoning support
+	if strings.HasPrefix(m.ID, "gemini-2.5-") || strings.HasPrefix(m.ID, "gemini-3-") {
+		return true, []string{"low", "medium", "high"}, "medium"
+	}
+
+	// GPT-5 series with reasoning levels
+	if strings.HasPrefix(m.ID, "gpt-5") && !strings.Contains(m.ID, "chat") {
+		return true, []string{"low", "medium", "high"}, "medium"
+	}
+
+	// OpenAI o-series with reasoning levels
+	if strings.HasPrefix(m.ID, "o3-") || strings.HasPrefix(m.ID, "o4-") {
+		return true, []string{"low", "medium", "high"}, "medium"
+	}
+
+	// DeepSeek R1 models
+	if strings.HasPrefix(m.ID, "deepseek-r1") {
+		return true, nil, ""
+	}
+
+	// Grok models with reasoning
+	if m.ID == "grok-3-mini" || m.ID == "grok-3-mini-beta" ||
+		strings.HasPrefix(m.ID, "grok-4") ||
+		m.ID == "grok-code-fast-1" {
+		return true, []string{"low", "medium", "high"}, "medium"
+	}
+
+	return false, nil, ""
+}
+
+func copilotAttachmentSupport(m copilotModel) bool {
+	// Claude models support attachments (vision/multimodal)
+	if strings.HasPrefix(m.ID, "claude-") {
+		return true
+	}
+
+	// Gemini models support attachments (vision/multimodal)
+	if strings.HasPrefix(m.ID, "gemini-") {
+		return true
+	}
+
+	// GPT-5 models support attachments (based on OpenRouter pattern)
+	if strings.HasPrefix(m.ID, "gpt-5") {
+		return true
+	}
+
+	// Older GPT models do not support attachments
+	if strings.HasPrefix(m.ID, "gpt-4") || strings.HasPrefix(m.ID, "gpt-3.5") {
+		return false
+	}
+
+	// Grok models - only grok-4 supports attachments
+	if m.ID == "grok-4" || strings.HasPrefix(m.ID, "grok-4-") {
+		return true
+	}
+
+	return false
+}
+
+func copilotToken() string {
+	if token := os.Getenv("COPILOT_TOKEN"); token != "" {
+		return token
+	}
+	return copilotTokenFromDisk()
+}
+
+func copilotTokenFromDisk() string {
+	data, err := os.ReadFile(copilotTokenFilePath())
+	if err != nil {
+		return ""
+	}
+	var content map[string]struct {
+		User        string `json:"user"`
+		OAuthToken  string `json:"oauth_token"`
+		GitHubAppID string `json:"githubAppId"`
+	}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return ""
+	}
+	if app, ok := content["github.com:Iv1.b507a08c87ecfe98"]; ok {
+		return app.OAuthToken
+	}
+	return ""
+}
+
+func copilotTokenFilePath() string {
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(os.Getenv("LOCALAPPDATA"), "github-copilot/apps.json")
+	default:
+		return filepath.Join(os.Getenv("HOME"), ".config/github-copilot/apps.json")
+	}
+}
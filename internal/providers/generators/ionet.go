@@ -0,0 +1,132 @@
+package generators
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/fetcher"
+	xstrings "github.com/charmbracelet/x/exp/strings"
+)
+
+func init() { fetcher.Register(IonetSource{}) }
+
+// ionetMinContextWindow is the smallest context window worth surfacing;
+// smaller models are dropped in Normalize.
+const ionetMinContextWindow = 20000
+
+// ionetModel represents a model from the io.net API.
+type ionetModel struct {
+	ID                   string  `json:"id"`
+	ContextWindow        int64   `json:"context_window"`
+	SupportsImagesInput  bool    `json:"supports_images_input"`
+	InputTokenPrice      float64 `json:"input_token_price"`
+	OutputTokenPrice     float64 `json:"output_token_price"`
+	CacheWriteTokenPrice float64 `json:"cache_write_token_price"`
+	CacheReadTokenPrice  float64 `json:"cache_read_token_price"`
+}
+
+// ionetModelsResponse is the response structure for the io.net models API.
+type ionetModelsResponse struct {
+	Data []ionetModel `json:"data"`
+}
+
+// IonetSource adapts io.net's /models endpoint to fetcher.Source.
+type IonetSource struct{}
+
+func (IonetSource) ProviderID() catwalk.InferenceProvider { return "ionet" }
+
+func (IonetSource) Endpoint() string {
+	return "https://api.intelligence.io.solutions/api/v1/models"
+}
+
+func (IonetSource) Provider() catwalk.Provider {
+	return catwalk.Provider{
+		Name:                "io.net",
+		ID:                  "ionet",
+		APIKey:              "$IONET_API_KEY",
+		APIEndpoint:         "https://api.intelligence.io.solutions/api/v1",
+		Type:                catwalk.TypeOpenAICompat,
+		DefaultLargeModelID: "zai-org/GLM-4.7",
+		DefaultSmallModelID: "zai-org/GLM-4.7-Flash",
+	}
+}
+
+func (IonetSource) Decode(body io.Reader) ([]fetcher.RawModel, error) {
+	var mr ionetModelsResponse
+	if err := json.NewDecoder(body).Decode(&mr); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	raw := make([]fetcher.RawModel, 0, len(mr.Data))
+	for _, m := range mr.Data {
+		raw = append(raw, fetcher.RawModel{ID: m.ID, Data: m})
+	}
+	return raw, nil
+}
+
+func (IonetSource) Normalize(r fetcher.RawModel) (catwalk.Model, bool) {
+	model := r.Data.(ionetModel) //nolint:forcetypeassert
+
+	if !fetcher.MinContextWindow(model.ContextWindow, ionetMinContextWindow) {
+		return catwalk.Model{}, false
+	}
+	if !ionetSupportsTools(model.ID) {
+		return catwalk.Model{}, false
+	}
+
+	canReason := ionetIsReasoningModel(model.ID)
+	levels, defaultReasoning := fetcher.ReasoningDefaults(canReason)
+
+	return catwalk.Model{
+		ID:                     model.ID,
+		Name:                   ionetModelName(model.ID),
+		CostPer1MIn:            model.InputTokenPrice * 1_000_000,
+		CostPer1MOut:           model.OutputTokenPrice * 1_000_000,
+		CostPer1MInCached:      model.CacheReadTokenPrice * 1_000_000,
+		CostPer1MOutCached:     model.CacheWriteTokenPrice * 1_000_000,
+		ContextWindow:          model.ContextWindow,
+		DefaultMaxTokens:       fetcher.DefaultMaxTokensFromContext(model.ContextWindow, 10, 0),
+		CanReason:              canReason,
+		ReasoningLevels:        levels,
+		DefaultReasoningEffort: defaultReasoning,
+		SupportsImages:         model.SupportsImagesInput,
+	}, true
+}
+
+// ionetModelName extracts a clean display name from the model ID: strip
+// everything before the last /, then replace hyphens with spaces.
+func ionetModelName(modelID string) string {
+	name := modelID
+	if idx := strings.LastIndex(modelID, "/"); idx != -1 {
+		name = modelID[idx+1:]
+	}
+	return strings.ReplaceAll(name, "-", " ")
+}
+
+// ionetIsReasoningModel checks if the model ID indicates reasoning
+// capability.
+func ionetIsReasoningModel(modelID string) bool {
+	return xstrings.ContainsAnyOf(
+		strings.ToLower(modelID),
+		"-thinking",
+		"deepseek",
+		"glm",
+		"gpt-oss",
+		"llama",
+	)
+}
+
+// ionetSupportsTools determines if a model supports tool calling based on
+// its ID.
+func ionetSupportsTools(modelID string) bool {
+	return !xstrings.ContainsAnyOf(
+		strings.ToLower(modelID),
+		"deepseek",
+		"llama-4",
+		"mistral-nemo",
+		"qwen2.5",
+		"gpt-oss",
+	)
+}
@@ -0,0 +1,135 @@
+package generators
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/fetcher"
+)
+
+func init() { fetcher.Register(OpenRouterSource{}) }
+
+// openRouterModel represents the complete model configuration.
+type openRouterModel struct {
+	ID              string                 `json:"id"`
+	CanonicalSlug   string                 `json:"canonical_slug"`
+	HuggingFaceID   string                 `json:"hugging_face_id"`
+	Name            string                 `json:"name"`
+	Created         int64                  `json:"created"`
+	Description     string                 `json:"description"`
+	ContextLength   int64                  `json:"context_length"`
+	Architecture    openRouterArchitecture `json:"architecture"`
+	Pricing         openRouterPricing      `json:"pricing"`
+	TopProvider     openRouterTopProvider  `json:"top_provider"`
+	SupportedParams []string               `json:"supported_parameters"`
+}
+
+// openRouterArchitecture defines the model's architecture details.
+type openRouterArchitecture struct {
+	Modality         string   `json:"modality"`
+	InputModalities  []string `json:"input_modalities"`
+	OutputModalities []string `json:"output_modalities"`
+	Tokenizer        string   `json:"tokenizer"`
+	InstructType     *string  `json:"instruct_type"`
+}
+
+// openRouterPricing contains the pricing information for different
+// operations.
+type openRouterPricing struct {
+	Prompt            string `json:"prompt"`
+	Completion        string `json:"completion"`
+	Request           string `json:"request"`
+	Image             string `json:"image"`
+	WebSearch         string `json:"web_search"`
+	InternalReasoning string `json:"internal_reasoning"`
+	InputCacheRead    string `json:"input_cache_read"`
+	InputCacheWrite   string `json:"input_cache_write"`
+}
+
+// openRouterTopProvider describes the top provider's capabilities.
+type openRouterTopProvider struct {
+	ContextLength       int64  `json:"context_length"`
+	MaxCompletionTokens *int64 `json:"max_completion_tokens"`
+	IsModerated         bool   `json:"is_moderated"`
+}
+
+// openRouterModelsResponse is the response structure for the models API.
+type openRouterModelsResponse struct {
+	Data []openRouterModel `json:"data"`
+}
+
+func openRouterPricingFor(model openRouterModel) (costIn, costOut, costInCached, costOutCached float64) {
+	return fetcher.ParsePer1M(model.Pricing.Prompt),
+		fetcher.ParsePer1M(model.Pricing.Completion),
+		fetcher.ParsePer1M(model.Pricing.InputCacheRead),
+		fetcher.ParsePer1M(model.Pricing.InputCacheWrite)
+}
+
+// OpenRouterSource adapts OpenRouter's /api/v1/models endpoint to
+// fetcher.Source.
+type OpenRouterSource struct{}
+
+func (OpenRouterSource) ProviderID() catwalk.InferenceProvider {
+	return catwalk.InferenceProviderOpenRouter
+}
+
+func (OpenRouterSource) Endpoint() string { return "https://openrouter.ai/api/v1/models" }
+
+func (OpenRouterSource) Provider() catwalk.Provider {
+	return catwalk.Provider{
+		Name:                "OpenRouter",
+		ID:                  catwalk.InferenceProviderOpenRouter,
+		APIKey:              "$OPENROUTER_API_KEY",
+		APIEndpoint:         "https://openrouter.ai/api/v1",
+		Type:                catwalk.TypeOpenAI,
+		DefaultLargeModelID: "anthropic/claude-sonnet-4",
+		DefaultSmallModelID: "anthropic/claude-haiku-3.5",
+	}
+}
+
+func (OpenRouterSource) Decode(body io.Reader) ([]fetcher.RawModel, error) {
+	var mr openRouterModelsResponse
+	if err := json.NewDecoder(body).Decode(&mr); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	raw := make([]fetcher.RawModel, 0, len(mr.Data))
+	for _, m := range mr.Data {
+		raw = append(raw, fetcher.RawModel{ID: m.ID, Data: m})
+	}
+	return raw, nil
+}
+
+func (OpenRouterSource) Normalize(r fetcher.RawModel) (catwalk.Model, bool) {
+	model := r.Data.(openRouterModel) //nolint:forcetypeassert
+
+	// Skip non-text models or those without tool support.
+	if !slices.Contains(model.SupportedParams, "tools") ||
+		!slices.Contains(model.Architecture.InputModalities, "text") ||
+		!slices.Contains(model.Architecture.OutputModalities, "text") {
+		return catwalk.Model{}, false
+	}
+
+	costIn, costOut, costInCached, costOutCached := openRouterPricingFor(model)
+
+	m := catwalk.Model{
+		ID:                 model.ID,
+		Name:               model.Name,
+		CostPer1MIn:        costIn,
+		CostPer1MOut:       costOut,
+		CostPer1MInCached:  costInCached,
+		CostPer1MOutCached: costOutCached,
+		ContextWindow:      model.ContextLength,
+		CanReason:          slices.Contains(model.SupportedParams, "reasoning"),
+		SupportsImages:     slices.Contains(model.Architecture.InputModalities, "image"),
+	}
+	if model.TopProvider.MaxCompletionTokens != nil {
+		m.DefaultMaxTokens = *model.TopProvider.MaxCompletionTokens / 2
+	} else {
+		m.DefaultMaxTokens = model.ContextLength / 10
+	}
+
+	return m, true
+}
@@ -0,0 +1,176 @@
+package generators
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/fetcher"
+)
+
+func init() { fetcher.Register(VeniceSource{}) }
+
+// veniceModelsResponse is the response from Venice's /models endpoint.
+type veniceModelsResponse struct {
+	Data []veniceModel `json:"data"`
+}
+
+// veniceModel represents a model from the Venice API.
+type veniceModel struct {
+	Created   int64           `json:"created"`
+	ID        string          `json:"id"`
+	ModelSpec veniceModelSpec `json:"model_spec"`
+	Object    string          `json:"object"`
+	OwnedBy   string          `json:"owned_by"`
+	Type      string          `json:"type"`
+}
+
+// veniceModelSpec carries the capability, pricing and constraint metadata
+// Venice attaches to each model.
+type veniceModelSpec struct {
+	AvailableContextTokens int64                  `json:"availableContextTokens"`
+	Capabilities           veniceCapabilities     `json:"capabilities"`
+	Constraints            veniceConstraints      `json:"constraints"`
+	Name                   string                 `json:"name"`
+	ModelSource            string                 `json:"modelSource"`
+	Offline                bool                   `json:"offline"`
+	Pricing                veniceModelSpecPricing `json:"pricing"`
+	Traits                 []string               `json:"traits"`
+}
+
+// veniceCapabilities describes what a Venice model supports.
+type veniceCapabilities struct {
+	OptimizedForCode        bool   `json:"optimizedForCode"`
+	Quantization            string `json:"quantization"`
+	SupportsFunctionCalling bool   `json:"supportsFunctionCalling"`
+	SupportsReasoning       bool   `json:"supportsReasoning"`
+	SupportsResponseSchema  bool   `json:"supportsResponseSchema"`
+	SupportsVision          bool   `json:"supportsVision"`
+	SupportsWebSearch       bool   `json:"supportsWebSearch"`
+	SupportsLogProbs        bool   `json:"supportsLogProbs"`
+}
+
+// veniceConstraints holds the default sampling parameters Venice
+// recommends for a model.
+type veniceConstraints struct {
+	Temperature *veniceDefaultFloat `json:"temperature"`
+	TopP        *veniceDefaultFloat `json:"top_p"`
+}
+
+// veniceDefaultFloat wraps a single recommended float value.
+type veniceDefaultFloat struct {
+	Default float64 `json:"default"`
+}
+
+// veniceModelSpecPricing holds a model's input/output token pricing.
+type veniceModelSpecPricing struct {
+	Input  venicePricingValue `json:"input"`
+	Output venicePricingValue `json:"output"`
+}
+
+// venicePricingValue is a single price point, in USD or Venice's internal
+// Diem currency.
+type venicePricingValue struct {
+	USD  float64 `json:"usd"`
+	Diem float64 `json:"diem"`
+}
+
+// VeniceSource adapts Venice's /models endpoint to fetcher.Source.
+type VeniceSource struct{}
+
+func (VeniceSource) ProviderID() catwalk.InferenceProvider { return catwalk.InferenceProviderVenice }
+
+func (VeniceSource) Endpoint() string { return "https://api.venice.ai/api/v1/models" }
+
+func (VeniceSource) Provider() catwalk.Provider {
+	return catwalk.Provider{
+		Name:        "Venice AI",
+		ID:          catwalk.InferenceProviderVenice,
+		APIKey:      "$VENICE_API_KEY",
+		APIEndpoint: "https://api.venice.ai/api/v1",
+		Type:        catwalk.TypeOpenAICompat,
+	}
+}
+
+func (VeniceSource) Headers() map[string]string {
+	apiKey := strings.TrimSpace(os.Getenv("VENICE_API_KEY"))
+	if apiKey == "" || strings.HasPrefix(apiKey, "$") {
+		return nil
+	}
+	return map[string]string{"Authorization": "Bearer " + apiKey}
+}
+
+func (VeniceSource) Decode(body io.Reader) ([]fetcher.RawModel, error) {
+	var mr veniceModelsResponse
+	if err := json.NewDecoder(body).Decode(&mr); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	raw := make([]fetcher.RawModel, 0, len(mr.Data))
+	for _, m := range mr.Data {
+		raw = append(raw, fetcher.RawModel{ID: m.ID, Data: m})
+	}
+	return raw, nil
+}
+
+func (VeniceSource) Normalize(r fetcher.RawModel) (catwalk.Model, bool) {
+	model := r.Data.(veniceModel) //nolint:forcetypeassert
+
+	if strings.ToLower(model.Type) != "text" {
+		return catwalk.Model{}, false
+	}
+	if model.ModelSpec.Offline {
+		return catwalk.Model{}, false
+	}
+	if !model.ModelSpec.Capabilities.SupportsFunctionCalling {
+		return catwalk.Model{}, false
+	}
+
+	contextWindow := model.ModelSpec.AvailableContextTokens
+	if contextWindow <= 0 {
+		return catwalk.Model{}, false
+	}
+
+	defaultMaxTokens := max(fetcher.ClampMaxTokens(contextWindow/4, 32768), 2048)
+
+	canReason := model.ModelSpec.Capabilities.SupportsReasoning
+	var reasoningLevels []string
+	var defaultReasoning string
+	if canReason {
+		reasoningLevels = []string{"low", "medium", "high"}
+		defaultReasoning = "medium"
+	}
+
+	options := catwalk.ModelOptions{}
+	if model.ModelSpec.Constraints.Temperature != nil {
+		if v := model.ModelSpec.Constraints.Temperature.Default; !math.IsNaN(v) {
+			options.Temperature = &v
+		}
+	}
+	if model.ModelSpec.Constraints.TopP != nil {
+		if v := model.ModelSpec.Constraints.TopP.Default; !math.IsNaN(v) {
+			options.TopP = &v
+		}
+	}
+
+	return catwalk.Model{
+		ID:                     model.ID,
+		Name:                   model.ModelSpec.Name,
+		CostPer1MIn:            model.ModelSpec.Pricing.Input.USD,
+		CostPer1MOut:           model.ModelSpec.Pricing.Output.USD,
+		ContextWindow:          contextWindow,
+		DefaultMaxTokens:       defaultMaxTokens,
+		CanReason:              canReason,
+		ReasoningLevels:        reasoningLevels,
+		DefaultReasoningEffort: defaultReasoning,
+		SupportsImages:         model.ModelSpec.Capabilities.SupportsVision,
+		Options:                options,
+	}, true
+}
+
+func (VeniceSource) PickDefaults(models []catwalk.Model) (large, small string) {
+	return fetcher.LargestContext()(models)
+}
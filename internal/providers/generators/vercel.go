@@ -0,0 +1,151 @@
+package generators
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/fetcher"
+)
+
+func init() { fetcher.Register(VercelSource{}) }
+
+// vercelModel represents a model from the Vercel API.
+type vercelModel struct {
+	ID            string        `json:"id"`
+	Object        string        `json:"object"`
+	Created       int64         `json:"created"`
+	OwnedBy       string        `json:"owned_by"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	ContextWindow int64         `json:"context_window"`
+	MaxTokens     int64         `json:"max_tokens"`
+	Type          string        `json:"type"`
+	Tags          []string      `json:"tags"`
+	Pricing       vercelPricing `json:"pricing"`
+}
+
+// vercelPricing contains the pricing information for a model.
+type vercelPricing struct {
+	Input           string `json:"input,omitempty"`
+	Output          string `json:"output,omitempty"`
+	InputCacheRead  string `json:"input_cache_read,omitempty"`
+	InputCacheWrite string `json:"input_cache_write,omitempty"`
+	WebSearch       string `json:"web_search,omitempty"`
+	Image           string `json:"image,omitempty"`
+}
+
+// vercelModelsResponse is the response structure for the Vercel models API.
+type vercelModelsResponse struct {
+	Object string        `json:"object"`
+	Data   []vercelModel `json:"data"`
+}
+
+// VercelSource adapts Vercel AI Gateway's /v1/models endpoint to
+// fetcher.Source.
+type VercelSource struct{}
+
+func (VercelSource) ProviderID() catwalk.InferenceProvider { return catwalk.InferenceProviderVercel }
+
+func (VercelSource) Endpoint() string { return "https://ai-gateway.vercel.sh/v1/models" }
+
+func (VercelSource) Provider() catwalk.Provider {
+	return catwalk.Provider{
+		Name:                "Vercel",
+		ID:                  catwalk.InferenceProviderVercel,
+		APIKey:              "$VERCEL_API_KEY",
+		APIEndpoint:         "https://ai-gateway.vercel.sh/v1",
+		Type:                catwalk.TypeVercel,
+		DefaultLargeModelID: "anthropic/claude-sonnet-4",
+		DefaultSmallModelID: "anthropic/claude-haiku-4.5",
+		DefaultHeaders: map[string]string{
+			"HTTP-Referer": "https://charm.land",
+			"X-Title":      "Crush",
+		},
+	}
+}
+
+func (VercelSource) Decode(body io.Reader) ([]fetcher.RawModel, error) {
+	var mr vercelModelsResponse
+	if err := json.NewDecoder(body).Decode(&mr); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	raw := make([]fetcher.RawModel, 0, len(mr.Data))
+	for _, m := range mr.Data {
+		raw = append(raw, fetcher.RawModel{ID: m.ID, Data: m})
+	}
+	return raw, nil
+}
+
+func (VercelSource) Normalize(r fetcher.RawModel) (catwalk.Model, bool) {
+	model := r.Data.(vercelModel) //nolint:forcetypeassert
+
+	base := catwalk.Model{
+		ID:                 model.ID,
+		Name:               model.Name,
+		CostPer1MIn:        fetcher.ParsePer1M(model.Pricing.Input),
+		CostPer1MOut:       fetcher.ParsePer1M(model.Pricing.Output),
+		CostPer1MInCached:  fetcher.ParsePer1M(model.Pricing.InputCacheRead),
+		CostPer1MOutCached: fetcher.ParsePer1M(model.Pricing.InputCacheWrite),
+		ContextWindow:      model.ContextWindow,
+	}
+
+	switch model.Type {
+	case "embedding":
+		base.Kind = catwalk.KindEmbedding
+		base.Embedding = &catwalk.EmbeddingSpec{MaxInputTokens: model.ContextWindow}
+		return base, true
+	case "image":
+		base.Kind = catwalk.KindImage
+		base.Image = &catwalk.ImageSpec{}
+		return base, true
+	}
+
+	// Everything else is treated as a chat model, and chat models need tool
+	// support to be useful to Crush.
+	if !slices.Contains(model.Tags, "tool-use") {
+		return catwalk.Model{}, false
+	}
+
+	canReason := slices.Contains(model.Tags, "reasoning")
+	var reasoningLevels []string
+	var defaultReasoning string
+	if canReason {
+		reasoningLevels = []string{"low", "medium", "high"}
+		if strings.HasPrefix(model.ID, "anthropic/") {
+			reasoningLevels = []string{"none", "minimal", "low", "medium", "high", "xhigh"}
+		}
+		defaultReasoning = "medium"
+	}
+
+	defaultMaxTokens := model.MaxTokens
+	if defaultMaxTokens == 0 {
+		defaultMaxTokens = model.ContextWindow / 10
+	}
+	defaultMaxTokens = fetcher.ClampMaxTokens(defaultMaxTokens, 8000)
+
+	supportsImages := slices.Contains(model.Tags, "vision")
+	inputModalities := []catwalk.Modality{catwalk.ModalityText}
+	if supportsImages {
+		inputModalities = append(inputModalities, catwalk.ModalityImage)
+	}
+
+	base.DefaultMaxTokens = defaultMaxTokens
+	base.CanReason = canReason
+	base.ReasoningLevels = reasoningLevels
+	base.DefaultReasoningEffort = defaultReasoning
+	base.SupportsImages = supportsImages
+	base.Capabilities = catwalk.Capabilities{
+		// tool-use is required above for every chat model that reaches here.
+		SupportsTools:         true,
+		SupportsStreaming:     true,
+		SupportsPromptCaching: model.Pricing.InputCacheRead != "" || model.Pricing.InputCacheWrite != "",
+		InputModalities:       inputModalities,
+		OutputModalities:      []catwalk.Modality{catwalk.ModalityText},
+	}
+
+	return base, true
+}
@@ -0,0 +1,188 @@
+package generators
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/fetcher"
+)
+
+func init() { fetcher.Register(ZenMuxSource{}) }
+
+// zenMuxPricing represents a single pricing entry.
+type zenMuxPricing struct {
+	Value    float64 `json:"value"`
+	Unit     string  `json:"unit"`
+	Currency string  `json:"currency"`
+}
+
+// zenMuxModelPricings contains all pricing information for a model.
+type zenMuxModelPricings struct {
+	Prompt              []zenMuxPricing `json:"prompt"`
+	Completion          []zenMuxPricing `json:"completion"`
+	InputCacheRead      []zenMuxPricing `json:"input_cache_read"`
+	InputCacheWrite5Min []zenMuxPricing `json:"input_cache_write_5_min"`
+	InputCacheWrite1H   []zenMuxPricing `json:"input_cache_write_1_h"`
+}
+
+// zenMuxCapabilities represents model capabilities.
+type zenMuxCapabilities struct {
+	Reasoning bool `json:"reasoning"`
+}
+
+// zenMuxModel represents a model from the ZenMux API with full details.
+type zenMuxModel struct {
+	ID               string              `json:"id"`
+	DisplayName      string              `json:"display_name"`
+	CreatedAt        string              `json:"created_at"`
+	Type             string              `json:"type"`
+	InputModalities  []string            `json:"input_modalities"`
+	OutputModalities []string            `json:"output_modalities"`
+	Capabilities     zenMuxCapabilities  `json:"capabilities"`
+	ContextLength    int64               `json:"context_length"`
+	Pricings         zenMuxModelPricings `json:"pricings"`
+}
+
+// zenMuxModelsResponse is the response from ZenMux's /api/anthropic/v1/models
+// endpoint.
+type zenMuxModelsResponse struct {
+	Data    []zenMuxModel `json:"data"`
+	HasMore bool          `json:"has_more"`
+}
+
+// zenMuxMinAuxModelContext is the context-window floor Normalize already
+// requires for any model, reused here so title/summary candidates are never
+// undersized.
+const zenMuxMinAuxModelContext = 20000
+
+// ZenMuxSource adapts ZenMux's /api/anthropic/v1/models endpoint to
+// fetcher.Source.
+type ZenMuxSource struct{}
+
+func (ZenMuxSource) ProviderID() catwalk.InferenceProvider { return "zenmux" }
+
+func (ZenMuxSource) Endpoint() string { return "https://zenmux.ai/api/anthropic/v1/models" }
+
+func (ZenMuxSource) Provider() catwalk.Provider {
+	return catwalk.Provider{
+		Name:                "ZenMux",
+		ID:                  "zenmux",
+		APIKey:              "$ZENMUX_API_KEY",
+		APIEndpoint:         "https://zenmux.ai/api/anthropic",
+		Type:                catwalk.TypeAnthropic,
+		DefaultLargeModelID: "anthropic/claude-sonnet-4.5",
+		DefaultSmallModelID: "anthropic/claude-3.5-haiku",
+	}
+}
+
+func (ZenMuxSource) Decode(body io.Reader) ([]fetcher.RawModel, error) {
+	var mr zenMuxModelsResponse
+	if err := json.NewDecoder(body).Decode(&mr); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	raw := make([]fetcher.RawModel, 0, len(mr.Data))
+	for _, m := range mr.Data {
+		raw = append(raw, fetcher.RawModel{ID: m.ID, Data: m})
+	}
+	return raw, nil
+}
+
+func (ZenMuxSource) Normalize(r fetcher.RawModel) (catwalk.Model, bool) {
+	model := r.Data.(zenMuxModel) //nolint:forcetypeassert
+
+	// Require at least 20k context and text I/O.
+	if !fetcher.MinContextWindow(model.ContextLength, zenMuxMinAuxModelContext) {
+		return catwalk.Model{}, false
+	}
+	if !slices.Contains(model.InputModalities, "text") ||
+		!slices.Contains(model.OutputModalities, "text") {
+		return catwalk.Model{}, false
+	}
+
+	pricing := func(p []zenMuxPricing) float64 {
+		if len(p) == 0 {
+			return 0
+		}
+		return p[0].Value
+	}
+
+	costIn := pricing(model.Pricings.Prompt)
+	costOut := pricing(model.Pricings.Completion)
+	costInCached := pricing(model.Pricings.InputCacheRead)
+	// Default the single-tier fields to the 5-minute cache write price, since
+	// it's the more commonly used tier; CachePricing below preserves both.
+	costOutCached := pricing(model.Pricings.InputCacheWrite5Min)
+
+	cachePricing := catwalk.CachePricing{}
+	if write5m := pricing(model.Pricings.InputCacheWrite5Min); write5m != 0 || costInCached != 0 {
+		cachePricing["5m"] = catwalk.CacheTierPricing{ReadPer1M: costInCached, WritePer1M: write5m}
+	}
+	if write1h := pricing(model.Pricings.InputCacheWrite1H); write1h != 0 {
+		cachePricing["1h"] = catwalk.CacheTierPricing{ReadPer1M: costInCached, WritePer1M: write1h}
+	}
+	if len(cachePricing) == 0 {
+		cachePricing = nil
+	}
+
+	canReason := model.Capabilities.Reasoning
+	defaultMaxTokens := fetcher.DefaultMaxTokensFromContext(model.ContextLength, 10, 20000)
+	if canReason {
+		// Reasoning models typically allow larger outputs.
+		defaultMaxTokens = fetcher.DefaultMaxTokensFromContext(model.ContextLength, 4, 50000)
+	}
+
+	return catwalk.Model{
+		ID:                 model.ID,
+		Name:               model.DisplayName,
+		CostPer1MIn:        costIn,
+		CostPer1MOut:       costOut,
+		CostPer1MInCached:  costInCached,
+		CostPer1MOutCached: costOutCached,
+		CachePricing:       cachePricing,
+		ContextWindow:      model.ContextLength,
+		DefaultMaxTokens:   defaultMaxTokens,
+		CanReason:          canReason,
+		SupportsImages:     slices.Contains(model.InputModalities, "image"),
+	}, true
+}
+
+// AssignRoles picks RoleTitle/RoleSummary as the cheapest text-only model
+// and RoleReasoning as the highest-context reasoning model, so downstream
+// clients don't have to hardcode ZenMux model IDs for these auxiliary
+// tasks.
+func (ZenMuxSource) AssignRoles(provider catwalk.Provider) catwalk.Provider {
+	var cheapest, bestReasoning *catwalk.Model
+	for i := range provider.Models {
+		m := &provider.Models[i]
+		if m.ContextWindow < zenMuxMinAuxModelContext {
+			continue
+		}
+
+		if !m.SupportsImages && !m.CanReason {
+			if cheapest == nil || m.CostPer1MIn+m.CostPer1MOut < cheapest.CostPer1MIn+cheapest.CostPer1MOut {
+				cheapest = m
+			}
+		}
+
+		if m.CanReason && (bestReasoning == nil || m.ContextWindow > bestReasoning.ContextWindow) {
+			bestReasoning = m
+		}
+	}
+
+	roles := map[catwalk.ModelRole]string{}
+	if cheapest != nil {
+		roles[catwalk.RoleTitle] = cheapest.ID
+		roles[catwalk.RoleSummary] = cheapest.ID
+	}
+	if bestReasoning != nil {
+		roles[catwalk.RoleReasoning] = bestReasoning.ID
+	}
+	if len(roles) > 0 {
+		provider.Roles = roles
+	}
+
+	return provider
+}
@@ -10,12 +10,15 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/catalog"
 )
 
 //go:embed configs/*.json
 var configsFS embed.FS
 
-// GetAll returns all registered providers.
+// GetAll returns all registered providers, with the in-tree and any
+// user-level catalog overlay (see pkg/catwalk/catalog) applied on top of
+// the embedded configs.
 func GetAll() []catwalk.Provider {
 	var providers []catwalk.Provider
 
@@ -46,5 +49,10 @@ func GetAll() []catwalk.Provider {
 		providers = append(providers, provider)
 	}
 
-	return providers
+	merged, err := catalog.LoadWithOverrides(providers, catalog.Options{})
+	if err != nil {
+		log.Printf("Error applying catalog overrides: %v", err)
+		return providers
+	}
+	return merged
 }
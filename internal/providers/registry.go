@@ -0,0 +1,300 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/catwalk/internal/etag"
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/fetcher"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var refreshFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "catwalk",
+	Subsystem: "providers",
+	Name:      "refresh_failures_total",
+	Help:      "Total number of failed background provider config refreshes.",
+})
+
+// snapshot is the immutable payload a Registry atomically swaps in.
+type snapshot struct {
+	json         []byte
+	etag         string
+	providers    []catwalk.Provider
+	gzip         []byte
+	zstd         []byte
+	lastModified time.Time
+}
+
+// Registry serves a catalog of providers that starts from the embedded
+// static configs and, given live sources, stays fresh without a
+// rebuild/redeploy: Run periodically re-fetches each source and swaps the
+// published snapshot in atomically. A source that fails to refresh just
+// keeps its last-good entry in the catalog.
+type Registry struct {
+	mu        sync.Mutex
+	providers map[catwalk.InferenceProvider]catwalk.Provider
+	sources   []fetcher.Source
+
+	current atomic.Pointer[snapshot]
+
+	watchMu  sync.Mutex
+	watchers map[chan []catwalk.Provider]struct{}
+
+	externalMu  sync.Mutex
+	external    map[int64]catwalk.Provider
+	externalSeq atomic.Int64
+}
+
+// NewRegistry builds a Registry seeded from the embedded static provider
+// configs (the same ones GetAll reads). sources, if given, are re-fetched
+// on every Run cycle to keep their entries current; providers with no
+// registered source are served as-is from the embedded configs.
+func NewRegistry(sources ...fetcher.Source) (*Registry, error) {
+	r := &Registry{
+		providers: make(map[catwalk.InferenceProvider]catwalk.Provider),
+		sources:   sources,
+		watchers:  make(map[chan []catwalk.Provider]struct{}),
+		external:  make(map[int64]catwalk.Provider),
+	}
+	for _, p := range GetAll() {
+		r.providers[p.ID] = p
+	}
+	if err := r.publish(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Subscribe registers a watcher that receives every snapshot the registry
+// publishes from now on (not the current one — callers that need it should
+// call Providers first, as the gRPC WatchProviders handler in pkg/catwalkpb
+// does), until ctx is done, at which point the channel is closed and
+// unregistered.
+func (r *Registry) Subscribe(ctx context.Context) <-chan []catwalk.Provider {
+	ch := make(chan []catwalk.Provider, 1)
+
+	r.watchMu.Lock()
+	r.watchers[ch] = struct{}{}
+	r.watchMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		r.watchMu.Lock()
+		delete(r.watchers, ch)
+		r.watchMu.Unlock()
+		close(ch)
+	}()
+
+	return ch
+}
+
+// notifyWatchers delivers a freshly published provider list to every
+// subscriber. A watcher that hasn't drained its previous update yet just
+// misses this one instead of blocking publish.
+func (r *Registry) notifyWatchers(provs []catwalk.Provider) {
+	r.watchMu.Lock()
+	defer r.watchMu.Unlock()
+	for ch := range r.watchers {
+		select {
+		case ch <- provs:
+		default:
+		}
+	}
+}
+
+// JSON returns the currently published, marshaled provider list.
+func (r *Registry) JSON() []byte {
+	return r.current.Load().json
+}
+
+// ETag returns the currently published provider list's ETag, already
+// quoted as an HTTP header value.
+func (r *Registry) ETag() string {
+	return r.current.Load().etag
+}
+
+// Providers returns the currently published provider list, sorted by ID.
+// Callers must treat the returned slice (and the Models slice nested in
+// each entry) as read-only.
+func (r *Registry) Providers() []catwalk.Provider {
+	return r.current.Load().providers
+}
+
+// Gzip returns the gzip encoding of the currently published JSON, computed
+// once at publish time.
+func (r *Registry) Gzip() []byte {
+	return r.current.Load().gzip
+}
+
+// Zstd returns the zstd encoding of the currently published JSON, computed
+// once at publish time.
+func (r *Registry) Zstd() []byte {
+	return r.current.Load().zstd
+}
+
+// LastModified returns when the currently published snapshot was produced.
+func (r *Registry) LastModified() time.Time {
+	return r.current.Load().lastModified
+}
+
+// Run refreshes the registry's live sources every interval, and immediately
+// whenever the process receives SIGHUP, until ctx is done.
+func (r *Registry) Run(ctx context.Context, interval time.Duration) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		case <-sighup:
+			log.Println("providers: SIGHUP received, refreshing now")
+			r.refresh(ctx)
+		}
+	}
+}
+
+// refresh re-fetches every registered live source and republishes the
+// catalog. A source whose fetch fails increments refreshFailuresTotal and
+// keeps serving whatever entry is already in the catalog.
+func (r *Registry) refresh(ctx context.Context) {
+	if len(r.sources) == 0 {
+		return
+	}
+
+	gen := fetcher.NewGenerator()
+	r.mu.Lock()
+	for _, src := range r.sources {
+		provider, _, notModified, err := gen.FetchProvider(ctx, src, "")
+		if err != nil {
+			refreshFailuresTotal.Inc()
+			log.Printf("providers: refreshing %s: %v", src.ProviderID(), err)
+			continue
+		}
+		if notModified {
+			continue
+		}
+		r.providers[src.ProviderID()] = provider
+	}
+	r.mu.Unlock()
+
+	if err := r.publish(); err != nil {
+		refreshFailuresTotal.Inc()
+		log.Printf("providers: publishing refreshed registry: %v", err)
+	}
+}
+
+// RegisterExternal adds provider to the catalog until the returned
+// unregister func is called, for pkg/catwalkpb's RegisterProvider gRPC
+// stream: an out-of-tree process can advertise a custom provider that
+// appears in the aggregated catalog for as long as its connection stays
+// open, without forking the repo or shipping a static config file. Two
+// calls with the same provider.ID don't collide -- each gets its own slot,
+// keyed by registration, not ID -- so the most recently published snapshot
+// wins if RegisterExternal and a static/live provider (or two external
+// registrations) share an ID.
+func (r *Registry) RegisterExternal(provider catwalk.Provider) (unregister func()) {
+	token := r.externalSeq.Add(1)
+
+	r.externalMu.Lock()
+	r.external[token] = provider
+	r.externalMu.Unlock()
+
+	if err := r.publish(); err != nil {
+		log.Printf("providers: publishing after RegisterExternal(%s): %v", provider.ID, err)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.externalMu.Lock()
+			delete(r.external, token)
+			r.externalMu.Unlock()
+
+			if err := r.publish(); err != nil {
+				log.Printf("providers: publishing after unregistering %s: %v", provider.ID, err)
+			}
+		})
+	}
+}
+
+// publish marshals the current provider map and atomically swaps it in,
+// but only if it actually differs from what's already published.
+func (r *Registry) publish() error {
+	byID := make(map[catwalk.InferenceProvider]catwalk.Provider)
+
+	r.mu.Lock()
+	for id, p := range r.providers {
+		byID[id] = p
+	}
+	r.mu.Unlock()
+
+	// External registrations are applied in registration order (token is a
+	// monotonic sequence number), so the most recently registered entry
+	// wins any collision -- with each other, or with a static/live provider
+	// sharing the same ID -- matching RegisterExternal's doc.
+	r.externalMu.Lock()
+	tokens := make([]int64, 0, len(r.external))
+	for token := range r.external {
+		tokens = append(tokens, token)
+	}
+	slices.Sort(tokens)
+	for _, token := range tokens {
+		p := r.external[token]
+		byID[p.ID] = p
+	}
+	r.externalMu.Unlock()
+
+	all := make([]catwalk.Provider, 0, len(byID))
+	for _, p := range byID {
+		all = append(all, p)
+	}
+
+	slices.SortFunc(all, func(a, b catwalk.Provider) int {
+		return strings.Compare(string(a.ID), string(b.ID))
+	})
+
+	data, err := json.Marshal(all)
+	if err != nil {
+		return fmt.Errorf("marshaling providers: %w", err)
+	}
+
+	if prev := r.current.Load(); prev != nil && string(prev.json) == string(data) {
+		return nil
+	}
+
+	gz, zst, err := compress(data)
+	if err != nil {
+		return fmt.Errorf("compressing providers: %w", err)
+	}
+
+	r.current.Store(&snapshot{
+		json:         data,
+		etag:         etag.Strong(data),
+		providers:    all,
+		gzip:         gz,
+		zstd:         zst,
+		lastModified: time.Now(),
+	})
+	r.notifyWatchers(all)
+	return nil
+}
@@ -0,0 +1,44 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+func TestRegistryPublishDedupesExternalByID(t *testing.T) {
+	r, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	const dupID = catwalk.InferenceProvider("dup-provider")
+
+	unregisterFirst := r.RegisterExternal(catwalk.Provider{ID: dupID, Name: "first"})
+	r.RegisterExternal(catwalk.Provider{ID: dupID, Name: "second"})
+
+	var found []catwalk.Provider
+	for _, p := range r.Providers() {
+		if p.ID == dupID {
+			found = append(found, p)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("Providers() has %d entries for %s, want 1", len(found), dupID)
+	}
+	if found[0].Name != "second" {
+		t.Errorf("Providers() kept %q for %s, want the most recently registered %q", found[0].Name, dupID, "second")
+	}
+
+	unregisterFirst()
+
+	found = nil
+	for _, p := range r.Providers() {
+		if p.ID == dupID {
+			found = append(found, p)
+		}
+	}
+	if len(found) != 1 || found[0].Name != "second" {
+		t.Fatalf("Providers() after unregistering the first registration = %v, want only %q to remain", found, "second")
+	}
+}
@@ -0,0 +1,41 @@
+package sync
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed overrides/*.json
+var overridesFS embed.FS
+
+// ModelOverride carries the manually curated fields that an upstream API
+// either omits or gets wrong for a specific model ID.
+type ModelOverride struct {
+	CostPer1MIn        *float64 `json:"cost_per_1m_in,omitempty"`
+	CostPer1MOut       *float64 `json:"cost_per_1m_out,omitempty"`
+	CostPer1MInCached  *float64 `json:"cost_per_1m_in_cached,omitempty"`
+	CostPer1MOutCached *float64 `json:"cost_per_1m_out_cached,omitempty"`
+	SupportedFeatures  []string `json:"supported_features,omitempty"`
+}
+
+// Overrides is the sidecar file format: a map of model ID to ModelOverride.
+// Keeping these in JSON checked into internal/providers/sync/overrides/
+// means maintainers can fix pricing or feature metadata without touching Go.
+type Overrides map[string]ModelOverride
+
+// LoadOverrides reads the overrides/<name>.json sidecar for the given
+// source name. A missing file is not an error: it just means the source has
+// no overrides yet.
+func LoadOverrides(name string) (Overrides, error) {
+	data, err := overridesFS.ReadFile(fmt.Sprintf("overrides/%s.json", name))
+	if err != nil {
+		return Overrides{}, nil //nolint:nilerr
+	}
+
+	var overrides Overrides
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("parsing overrides for %s: %w", name, err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,70 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+// Sources is the registry of all known ProviderSource implementations,
+// keyed by their Name(). Synthetic is the only one implemented here:
+// the OpenRouter-style aggregator and raw-OpenAI-compat cases this package
+// was generalized for ended up covered by pkg/catwalk/fetcher.Source
+// instead (see generators.OpenRouterSource and the Vercel/zen/aihubmix
+// ports onto that framework) once it landed, so they were never added as
+// second and third ProviderSource implementations here.
+var Sources = map[string]func() ProviderSource{
+	"synthetic": func() ProviderSource { return NewSyntheticSource() },
+}
+
+// Run fetches, normalizes and emits the config for a single named source.
+// It writes the primary provider JSON to
+// internal/providers/configs/<name>.json and then calls the source's Emit
+// hook for any additional files.
+func Run(ctx context.Context, name string) error {
+	newSource, ok := Sources[name]
+	if !ok {
+		return fmt.Errorf("unknown provider source %q", name)
+	}
+	source := newSource()
+
+	overrides, err := LoadOverrides(name)
+	if err != nil {
+		return err
+	}
+
+	raw, err := source.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", name, err)
+	}
+
+	provider := source.Provider()
+	for _, r := range raw {
+		model, ok := source.NormalizeModel(r, overrides)
+		if !ok {
+			continue
+		}
+		provider.Models = append(provider.Models, model)
+	}
+	slices.SortFunc(provider.Models, func(a, b catwalk.Model) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	data, err := json.MarshalIndent(provider, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling %s provider: %w", name, err)
+	}
+
+	path := filepath.Join("internal", "providers", "configs", name+".json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	return source.Emit(provider)
+}
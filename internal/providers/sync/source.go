@@ -0,0 +1,51 @@
+// Package sync provides a pluggable framework for syncing provider/model
+// catalogs from upstream APIs into catwalk provider config files.
+//
+// Each upstream (Synthetic, OpenRouter, a raw OpenAI-compatible endpoint, ...)
+// implements the ProviderSource interface. The shared Run driver takes care
+// of loading override sidecar files, invoking the source, and emitting the
+// resulting catwalk.Provider as JSON, so individual `cmd/<provider>/main.go`
+// tools no longer have to duplicate that plumbing.
+package sync
+
+import (
+	"context"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+// RawModel is the upstream representation of a single model, prior to
+// normalization into a catwalk.Model. Sources decode their API response into
+// whatever shape they like and hand back RawModels keyed by the fields
+// NormalizeModel needs.
+type RawModel struct {
+	// ID is the upstream model identifier, used to look up overrides.
+	ID string
+	// Data is the source-specific decoded payload (e.g. the JSON struct for
+	// that provider's /models response entry).
+	Data any
+}
+
+// ProviderSource is implemented once per upstream provider API.
+type ProviderSource interface {
+	// Name identifies the source, e.g. "synthetic". Used to select the
+	// override sidecar file and as the --provider flag value.
+	Name() string
+
+	// Fetch retrieves the raw model list from the upstream API.
+	Fetch(ctx context.Context) ([]RawModel, error)
+
+	// NormalizeModel converts a single raw model into a catwalk.Model. It may
+	// return ok=false to skip models that shouldn't be included (e.g. non-text
+	// modalities, context windows below a threshold).
+	NormalizeModel(raw RawModel, overrides Overrides) (model catwalk.Model, ok bool)
+
+	// Provider returns the base provider metadata (name, endpoint, API key
+	// env var, ...) that normalized models are attached to.
+	Provider() catwalk.Provider
+
+	// Emit writes out any additional config files beyond the primary
+	// provider JSON (e.g. Synthetic's Pro/Max zero-pricing variant). Sources
+	// that only produce a single file can make this a no-op.
+	Emit(provider catwalk.Provider) error
+}
@@ -0,0 +1,206 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+// syntheticModel is the upstream shape of a single Synthetic model entry.
+type syntheticModel struct {
+	ID                string          `json:"id"`
+	Name              string          `json:"name"`
+	InputModalities   []string        `json:"input_modalities"`
+	OutputModalities  []string        `json:"output_modalities"`
+	ContextLength     int64           `json:"context_length"`
+	MaxOutputLength   int64           `json:"max_output_length,omitempty"`
+	Pricing           syntheticPrices `json:"pricing"`
+	SupportedFeatures []string        `json:"supported_features,omitempty"`
+}
+
+type syntheticPrices struct {
+	Prompt           string `json:"prompt"`
+	Completion       string `json:"completion"`
+	InputCacheReads  string `json:"input_cache_reads"`
+	InputCacheWrites string `json:"input_cache_writes"`
+}
+
+// SyntheticSource fetches and normalizes models from the Synthetic API.
+type SyntheticSource struct {
+	Endpoint string
+}
+
+// NewSyntheticSource returns a ProviderSource for Synthetic.
+func NewSyntheticSource() *SyntheticSource {
+	return &SyntheticSource{Endpoint: "https://api.synthetic.new/openai/v1"}
+}
+
+// Name implements ProviderSource.
+func (s *SyntheticSource) Name() string { return "synthetic" }
+
+// Provider implements ProviderSource.
+func (s *SyntheticSource) Provider() catwalk.Provider {
+	return catwalk.Provider{
+		Name:                "Synthetic",
+		ID:                  catwalk.InferenceProviderSynthetic,
+		APIKey:              "$SYNTHETIC_API_KEY",
+		APIEndpoint:         s.Endpoint,
+		Type:                catwalk.TypeOpenAICompat,
+		DefaultLargeModelID: "hf:zai-org/GLM-4.7",
+		DefaultSmallModelID: "hf:deepseek-ai/DeepSeek-V3.1-Terminus",
+	}
+}
+
+// Fetch implements ProviderSource.
+func (s *SyntheticSource) Fetch(ctx context.Context) ([]RawModel, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.Endpoint+"/models", nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Crush-Client/1.0")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching synthetic models: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, body)
+	}
+
+	var parsed struct {
+		Data []syntheticModel `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("decoding synthetic models: %w", err)
+	}
+
+	raw := make([]RawModel, 0, len(parsed.Data))
+	for _, m := range parsed.Data {
+		raw = append(raw, RawModel{ID: m.ID, Data: m})
+	}
+	return raw, nil
+}
+
+// NormalizeModel implements ProviderSource.
+func (s *SyntheticSource) NormalizeModel(r RawModel, overrides Overrides) (catwalk.Model, bool) {
+	m, ok := r.Data.(syntheticModel)
+	if !ok {
+		return catwalk.Model{}, false
+	}
+
+	override, hasOverride := overrides[m.ID]
+	if hasOverride {
+		m.SupportedFeatures = override.SupportedFeatures
+	}
+
+	if m.ContextLength < 20000 {
+		return catwalk.Model{}, false
+	}
+	if !slices.Contains(m.InputModalities, "text") || !slices.Contains(m.OutputModalities, "text") {
+		return catwalk.Model{}, false
+	}
+	if !slices.Contains(m.SupportedFeatures, "tools") {
+		return catwalk.Model{}, false
+	}
+
+	costIn, costOut, costInCached, costOutCached := syntheticPricing(m)
+	if hasOverride {
+		if override.CostPer1MIn != nil {
+			costIn = *override.CostPer1MIn
+		}
+		if override.CostPer1MOut != nil {
+			costOut = *override.CostPer1MOut
+		}
+		if override.CostPer1MInCached != nil {
+			costInCached = *override.CostPer1MInCached
+		}
+		if override.CostPer1MOutCached != nil {
+			costOutCached = *override.CostPer1MOutCached
+		}
+	}
+
+	canReason := slices.Contains(m.SupportedFeatures, "reasoning")
+	var reasoningLevels []string
+	var defaultReasoning string
+	if canReason {
+		reasoningLevels = []string{"low", "medium", "high"}
+		defaultReasoning = "medium"
+	}
+
+	name := m.Name
+	if idx := strings.Index(name, "/"); idx != -1 {
+		name = name[idx+1:]
+	}
+	name = strings.ReplaceAll(name, "-", " ")
+
+	model := catwalk.Model{
+		ID:                     m.ID,
+		Name:                   name,
+		CostPer1MIn:            costIn,
+		CostPer1MOut:           costOut,
+		CostPer1MInCached:      costInCached,
+		CostPer1MOutCached:     costOutCached,
+		ContextWindow:          m.ContextLength,
+		CanReason:              canReason,
+		DefaultReasoningEffort: defaultReasoning,
+		ReasoningLevels:        reasoningLevels,
+		SupportsImages:         slices.Contains(m.InputModalities, "image"),
+	}
+
+	maxFromOutput := m.MaxOutputLength / 2
+	maxAt15Pct := (m.ContextLength * 15) / 100
+	if m.MaxOutputLength > 0 && maxFromOutput <= maxAt15Pct {
+		model.DefaultMaxTokens = maxFromOutput
+	} else {
+		model.DefaultMaxTokens = m.ContextLength / 10
+	}
+
+	return model, true
+}
+
+func syntheticPricing(m syntheticModel) (in, out, inCached, outCached float64) {
+	parse := func(s string) float64 {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0
+		}
+		return v * 1_000_000
+	}
+	return parse(m.Pricing.Prompt), parse(m.Pricing.Completion), parse(m.Pricing.InputCacheReads), parse(m.Pricing.InputCacheWrites)
+}
+
+// Emit implements ProviderSource. Besides the primary synthetic.json config,
+// Synthetic also publishes a Pro/Max variant with the same models at zero
+// subscription pricing.
+func (s *SyntheticSource) Emit(provider catwalk.Provider) error {
+	proMax := provider
+	proMax.Name = "Synthetic Pro/Max"
+	proMax.ID = "synthetic-promax"
+	proMax.Models = make([]catwalk.Model, len(provider.Models))
+	for i, m := range provider.Models {
+		m.CostPer1MIn = 0
+		m.CostPer1MOut = 0
+		m.CostPer1MInCached = 0
+		m.CostPer1MOutCached = 0
+		proMax.Models[i] = m
+	}
+
+	data, err := json.MarshalIndent(proMax, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling synthetic-promax: %w", err)
+	}
+	return os.WriteFile("internal/providers/configs/synthetic-promax.json", data, 0o600)
+}
@@ -0,0 +1,51 @@
+package sync
+
+import "testing"
+
+func TestSyntheticNormalizeModelAppliesOverrides(t *testing.T) {
+	source := NewSyntheticSource()
+	overrides := Overrides{
+		"hf:test/model": {
+			SupportedFeatures: []string{"tools", "reasoning"},
+		},
+	}
+
+	raw := RawModel{
+		ID: "hf:test/model",
+		Data: syntheticModel{
+			ID:               "hf:test/model",
+			Name:             "test/Test Model",
+			InputModalities:  []string{"text"},
+			OutputModalities: []string{"text"},
+			ContextLength:    128000,
+		},
+	}
+
+	model, ok := source.NormalizeModel(raw, overrides)
+	if !ok {
+		t.Fatal("expected model to be included after override adds tools support")
+	}
+	if !model.CanReason {
+		t.Error("expected model to support reasoning per override")
+	}
+	if model.Name != "Test Model" {
+		t.Errorf("expected name %q, got %q", "Test Model", model.Name)
+	}
+}
+
+func TestSyntheticNormalizeModelSkipsWithoutToolsSupport(t *testing.T) {
+	source := NewSyntheticSource()
+	raw := RawModel{
+		ID: "hf:test/no-tools",
+		Data: syntheticModel{
+			ID:               "hf:test/no-tools",
+			InputModalities:  []string{"text"},
+			OutputModalities: []string{"text"},
+			ContextLength:    128000,
+		},
+	}
+
+	if _, ok := source.NormalizeModel(raw, Overrides{}); ok {
+		t.Error("expected model without tools support to be skipped")
+	}
+}
@@ -3,100 +3,35 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"log"
 	"net/http"
 	"time"
 
-	"github.com/charmbracelet/catwalk/internal/deprecated"
-	"github.com/charmbracelet/catwalk/internal/etag"
+	"github.com/charmbracelet/catwalk/internal/httpapi"
 	"github.com/charmbracelet/catwalk/internal/providers"
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-var counter = promauto.NewCounter(prometheus.CounterOpts{
-	Namespace: "catwalk",
-	Subsystem: "providers",
-	Name:      "requests_total",
-	Help:      "Total number of requests to the providers endpoint",
-})
+// refreshInterval is how often the provider registry re-fetches its live
+// sources. There are none registered yet (Venice/Copilot still run as
+// one-shot generators, see cmd/venice and cmd/copilot), so today this just
+// governs how often SIGHUP-free refreshes are attempted once sources are
+// added.
+const refreshInterval = 15 * time.Minute
 
-var (
-	allProviders  = providers.GetAll()
-	providersJSON []byte
-	providersETag string
-)
-
-func init() {
-	var err error
-	providersJSON, err = json.Marshal(allProviders)
+func main() {
+	registry, err := providers.NewRegistry()
 	if err != nil {
-		log.Fatal("Failed to marshal providers:", err)
-	}
-	providersETag = fmt.Sprintf(`"%s"`, etag.Of(providersJSON))
-}
-
-func providersHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("ETag", providersETag)
-
-	if r.Method == http.MethodHead {
-		return
-	}
-
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	counter.Inc()
-
-	if match := r.Header.Get("If-None-Match"); match == providersETag {
-		w.WriteHeader(http.StatusNotModified)
-		return
+		log.Fatal("Failed to build provider registry:", err)
 	}
 
-	if _, err := w.Write(providersJSON); err != nil {
-		log.Printf("Error writing response: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
-}
-
-func providersHandlerDeprecated(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	if r.Method == http.MethodHead {
-		return
-	}
-
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	counter.Inc()
-	allProviders := deprecated.GetAll()
-	if err := json.NewEncoder(w).Encode(allProviders); err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-}
-
-func main() {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/v2/providers", providersHandler)
-	mux.HandleFunc("/providers", providersHandlerDeprecated)
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
-	mux.Handle("/metrics", promhttp.Handler())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go registry.Run(ctx, refreshInterval)
 
 	server := &http.Server{
 		Addr:         ":8080",
-		Handler:      mux,
+		Handler:      httpapi.NewMux(registry),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
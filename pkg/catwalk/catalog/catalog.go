@@ -0,0 +1,276 @@
+// Package catalog lets the pricing and capability numbers baked into a
+// provider's model list be tweaked without a catwalk release: an in-tree
+// overlay (shipped under pkg/catwalk/catalog/data) and an optional
+// user-level one ($XDG_CONFIG_HOME/catwalk/overrides.yaml) are merged onto
+// whatever providers.GetAll or a generator already produced, each one
+// overriding only the fields it sets.
+//
+// LoadWithOverrides lives here rather than as catwalk.LoadWithOverrides
+// because it needs catwalk.Provider/Model, and pkg/catwalk/catalog
+// importing pkg/catwalk while pkg/catwalk imported catalog back would be a
+// cycle -- the same reason configstore and fetcher are their own
+// subpackages instead of living in catwalk itself.
+package catalog
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data
+var dataFS embed.FS
+
+// ModelOverlay holds the subset of catwalk.Model fields a catalog entry can
+// override. Every field is a pointer so "unset" and "set to the zero
+// value" are distinguishable: an overlay only touches fields it sets.
+type ModelOverlay struct {
+	CostPer1MIn        *float64 `json:"cost_per_1m_in,omitempty" yaml:"cost_per_1m_in,omitempty"`
+	CostPer1MOut       *float64 `json:"cost_per_1m_out,omitempty" yaml:"cost_per_1m_out,omitempty"`
+	CostPer1MInCached  *float64 `json:"cost_per_1m_in_cached,omitempty" yaml:"cost_per_1m_in_cached,omitempty"`
+	CostPer1MOutCached *float64 `json:"cost_per_1m_out_cached,omitempty" yaml:"cost_per_1m_out_cached,omitempty"`
+	ContextWindow      *int64   `json:"context_window,omitempty" yaml:"context_window,omitempty"`
+	DefaultMaxTokens   *int64   `json:"default_max_tokens,omitempty" yaml:"default_max_tokens,omitempty"`
+	CanReason          *bool    `json:"can_reason,omitempty" yaml:"can_reason,omitempty"`
+	SupportsImages     *bool    `json:"supports_attachments,omitempty" yaml:"supports_attachments,omitempty"`
+
+	// The fields below override catwalk.Model.Capabilities, individually
+	// like everything above -- an overlay entry only needs to name the
+	// capabilities it's adding, not restate ones a generator (e.g.
+	// pkg/providers/bedrock) already populated, like InputModalities.
+	SupportsTools            *bool `json:"supports_tools,omitempty" yaml:"supports_tools,omitempty"`
+	SupportsParallelTools    *bool `json:"supports_parallel_tools,omitempty" yaml:"supports_parallel_tools,omitempty"`
+	SupportsJSONMode         *bool `json:"supports_json_mode,omitempty" yaml:"supports_json_mode,omitempty"`
+	SupportsStructuredOutput *bool `json:"supports_structured_output,omitempty" yaml:"supports_structured_output,omitempty"`
+	SupportsPromptCaching    *bool `json:"supports_prompt_caching,omitempty" yaml:"supports_prompt_caching,omitempty"`
+}
+
+// apply returns m with every field o sets overlaid on top of it.
+func (o ModelOverlay) apply(m catwalk.Model) catwalk.Model {
+	if o.CostPer1MIn != nil {
+		m.CostPer1MIn = *o.CostPer1MIn
+	}
+	if o.CostPer1MOut != nil {
+		m.CostPer1MOut = *o.CostPer1MOut
+	}
+	if o.CostPer1MInCached != nil {
+		m.CostPer1MInCached = *o.CostPer1MInCached
+	}
+	if o.CostPer1MOutCached != nil {
+		m.CostPer1MOutCached = *o.CostPer1MOutCached
+	}
+	if o.ContextWindow != nil {
+		m.ContextWindow = *o.ContextWindow
+	}
+	if o.DefaultMaxTokens != nil {
+		m.DefaultMaxTokens = *o.DefaultMaxTokens
+	}
+	if o.CanReason != nil {
+		m.CanReason = *o.CanReason
+	}
+	if o.SupportsImages != nil {
+		m.SupportsImages = *o.SupportsImages
+	}
+	if o.SupportsTools != nil {
+		m.Capabilities.SupportsTools = *o.SupportsTools
+	}
+	if o.SupportsParallelTools != nil {
+		m.Capabilities.SupportsParallelTools = *o.SupportsParallelTools
+	}
+	if o.SupportsJSONMode != nil {
+		m.Capabilities.SupportsJSONMode = *o.SupportsJSONMode
+	}
+	if o.SupportsStructuredOutput != nil {
+		m.Capabilities.SupportsStructuredOutput = *o.SupportsStructuredOutput
+	}
+	if o.SupportsPromptCaching != nil {
+		m.Capabilities.SupportsPromptCaching = *o.SupportsPromptCaching
+	}
+	return m
+}
+
+// Overlay is a set of per-model overrides, keyed by provider ID and then
+// model ID, as loaded from a single catalog file.
+type Overlay map[catwalk.InferenceProvider]map[string]ModelOverlay
+
+// Merge returns providers with every overlay in overlays applied in order,
+// so a later overlay wins over an earlier one for the same (provider,
+// model) pair. Callers wanting the precedence described in the package doc
+// should pass overlays upstream-first: Merge(providers, inTree, user).
+func Merge(providers []catwalk.Provider, overlays ...Overlay) []catwalk.Provider {
+	out := make([]catwalk.Provider, len(providers))
+	for i, p := range providers {
+		models := make([]catwalk.Model, len(p.Models))
+		copy(models, p.Models)
+
+		for _, overlay := range overlays {
+			perModel, ok := overlay[p.ID]
+			if !ok {
+				continue
+			}
+			for j, m := range models {
+				if o, ok := perModel[m.ID]; ok {
+					models[j] = o.apply(m)
+				}
+			}
+		}
+
+		p.Models = models
+		out[i] = p
+	}
+	return out
+}
+
+// LoadFile reads an Overlay from a YAML or JSON file, inferring the format
+// from path's extension the same way configstore.FormatFromPath does.
+func LoadFile(path string) (Overlay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return decode(data, path)
+}
+
+// InTree loads and merges every overlay file shipped under
+// pkg/catwalk/catalog/data, in directory order.
+func InTree() (Overlay, error) {
+	entries, err := fs.ReadDir(dataFS, "data")
+	if err != nil {
+		return nil, fmt.Errorf("reading in-tree catalog: %w", err)
+	}
+
+	merged := make(Overlay)
+	for _, e := range entries {
+		if e.IsDir() || !isOverlayFile(e.Name()) {
+			continue
+		}
+
+		data, err := dataFS.ReadFile(filepath.Join("data", e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading in-tree catalog %s: %w", e.Name(), err)
+		}
+
+		overlay, err := decode(data, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("parsing in-tree catalog %s: %w", e.Name(), err)
+		}
+		mergeInto(merged, overlay)
+	}
+	return merged, nil
+}
+
+// UserOverridesPath returns the path catwalk looks for an optional
+// user-level override file: $XDG_CONFIG_HOME/catwalk/overrides.yaml, or
+// os.UserConfigDir's catwalk/overrides.yaml if XDG_CONFIG_HOME is unset.
+func UserOverridesPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		d, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("resolving user config dir: %w", err)
+		}
+		dir = d
+	}
+	return filepath.Join(dir, "catwalk", "overrides.yaml"), nil
+}
+
+// Options controls which overlays LoadWithOverrides merges in, on top of
+// the in-tree catalog, and in what precedence (later entries win).
+type Options struct {
+	// CatalogFiles are additional overlay files merged in after the
+	// shipped in-tree catalog and before the user override. Most callers
+	// leave this nil.
+	CatalogFiles []string
+	// UserOverridePath overrides where the user-level overlay is read
+	// from. Defaults to UserOverridesPath().
+	UserOverridePath string
+	// SkipUserOverride disables loading the user-level overlay entirely,
+	// e.g. for tests that want a deterministic result regardless of the
+	// machine they run on.
+	SkipUserOverride bool
+}
+
+// LoadWithOverrides merges the in-tree catalog, opts.CatalogFiles and (
+// unless disabled) the user-level override onto providers, in that
+// precedence order, and returns the result. providers is left untouched;
+// only the returned slice (and its models) reflect the overlays.
+func LoadWithOverrides(providers []catwalk.Provider, opts Options) ([]catwalk.Provider, error) {
+	inTree, err := InTree()
+	if err != nil {
+		return nil, err
+	}
+	overlays := []Overlay{inTree}
+
+	for _, f := range opts.CatalogFiles {
+		overlay, err := LoadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("loading catalog file %s: %w", f, err)
+		}
+		overlays = append(overlays, overlay)
+	}
+
+	if !opts.SkipUserOverride {
+		path := opts.UserOverridePath
+		if path == "" {
+			path, err = UserOverridesPath()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		overlay, err := LoadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			// No user override file is the common case, not an error.
+		case err != nil:
+			return nil, fmt.Errorf("loading user overrides: %w", err)
+		default:
+			overlays = append(overlays, overlay)
+		}
+	}
+
+	return Merge(providers, overlays...), nil
+}
+
+func isOverlayFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+func decode(data []byte, path string) (Overlay, error) {
+	var overlay Overlay
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &overlay); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	default:
+		if err := json.Unmarshal(data, &overlay); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+	return overlay, nil
+}
+
+func mergeInto(dst, src Overlay) {
+	for providerID, models := range src {
+		if dst[providerID] == nil {
+			dst[providerID] = make(map[string]ModelOverlay, len(models))
+		}
+		for modelID, overlay := range models {
+			dst[providerID][modelID] = overlay
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+func TestMergePrecedence(t *testing.T) {
+	providers := []catwalk.Provider{
+		{
+			ID: "zen",
+			Models: []catwalk.Model{
+				{ID: "gpt-5-nano", CostPer1MIn: 1, ContextWindow: 128000},
+			},
+		},
+	}
+
+	inTree := Overlay{
+		"zen": {"gpt-5-nano": ModelOverlay{CostPer1MIn: ptr(0.5), ContextWindow: ptr[int64](200000)}},
+	}
+	user := Overlay{
+		"zen": {"gpt-5-nano": ModelOverlay{CostPer1MIn: ptr(0.05)}},
+	}
+
+	merged := Merge(providers, inTree, user)
+
+	got := merged[0].Models[0]
+	if got.CostPer1MIn != 0.05 {
+		t.Errorf("CostPer1MIn = %v, want user override 0.05", got.CostPer1MIn)
+	}
+	if got.ContextWindow != 200000 {
+		t.Errorf("ContextWindow = %v, want in-tree override 200000", got.ContextWindow)
+	}
+
+	// The original slice must be untouched.
+	if providers[0].Models[0].CostPer1MIn != 1 {
+		t.Errorf("Merge mutated its input: CostPer1MIn = %v, want 1", providers[0].Models[0].CostPer1MIn)
+	}
+}
+
+func TestMergeUnknownModelIsNoop(t *testing.T) {
+	providers := []catwalk.Provider{
+		{ID: "zen", Models: []catwalk.Model{{ID: "gpt-5-nano", CostPer1MIn: 1}}},
+	}
+	overlay := Overlay{"zen": {"does-not-exist": ModelOverlay{CostPer1MIn: ptr(0.5)}}}
+
+	merged := Merge(providers, overlay)
+	if merged[0].Models[0].CostPer1MIn != 1 {
+		t.Errorf("CostPer1MIn = %v, want unchanged 1", merged[0].Models[0].CostPer1MIn)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
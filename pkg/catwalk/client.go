@@ -4,36 +4,46 @@ import (
 	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 
 	"github.com/charmbracelet/catwalk/internal/etag"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/httpx"
 )
 
 const defaultURL = "http://localhost:8080"
 
 // Client represents a client for the catwalk service.
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL     string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
 }
 
 // New creates a new client instance
 // Uses CATWALK_URL environment variable or falls back to localhost:8080.
-func New() *Client {
-	return &Client{
-		baseURL:    cmp.Or(os.Getenv("CATWALK_URL"), defaultURL),
-		httpClient: &http.Client{},
-	}
+func New(opts ...Option) *Client {
+	return newClient(cmp.Or(os.Getenv("CATWALK_URL"), defaultURL), opts)
 }
 
 // NewWithURL creates a new client with a specific URL.
-func NewWithURL(url string) *Client {
-	return &Client{
-		baseURL:    url,
-		httpClient: &http.Client{},
+func NewWithURL(url string, opts ...Option) *Client {
+	return newClient(url, opts)
+}
+
+func newClient(baseURL string, opts []Option) *Client {
+	c := &Client{
+		baseURL:     baseURL,
+		httpClient:  &http.Client{},
+		retryPolicy: defaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // ErrNotModified happens when the given ETag matches the server, so no update
@@ -43,41 +53,114 @@ var ErrNotModified = fmt.Errorf("not modified")
 // Etag returns the ETag for the given data.
 func Etag(data []byte) string { return etag.Of(data) }
 
-// GetProviders retrieves all available providers from the service.
+// GetProviders retrieves all available providers from the service. The
+// request is retried per the Client's RetryPolicy on transport errors and
+// retryable status codes, honoring ctx cancellation/deadlines between
+// attempts.
 func (c *Client) GetProviders(ctx context.Context, etag string) ([]Provider, error) {
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v2/providers", c.baseURL),
-		nil,
-	)
+	body, err := c.getWithRetry(ctx, fmt.Sprintf("%s/v2/providers", c.baseURL), etag)
 	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+		return nil, err
 	}
+	if body == nil {
+		return nil, ErrNotModified
+	}
+	defer body.Close() //nolint:errcheck
 
-	if etag != "" {
-		// It needs to be quoted:
-		req.Header.Add("If-None-Match", fmt.Sprintf(`"%s"`, etag))
+	var providers []Provider
+	if err := json.NewDecoder(body).Decode(&providers); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	return providers, nil
+}
+
+// GetProvider retrieves a single provider by ID. It behaves like
+// GetProviders in every other respect, including honoring etag and
+// returning ErrNotModified.
+func (c *Client) GetProvider(ctx context.Context, id InferenceProvider, etag string) (Provider, error) {
+	body, err := c.getWithRetry(ctx, fmt.Sprintf("%s/v2/providers/%s", c.baseURL, id), etag)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return Provider{}, err
+	}
+	if body == nil {
+		return Provider{}, ErrNotModified
 	}
-	defer resp.Body.Close() //nolint:errcheck
+	defer body.Close() //nolint:errcheck
 
-	if resp.StatusCode == http.StatusNotModified {
-		return nil, ErrNotModified
+	var provider Provider
+	if err := json.NewDecoder(body).Decode(&provider); err != nil {
+		return Provider{}, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	return provider, nil
+}
+
+// GetProvidersCached behaves like GetProviders, but maintains an on-disk
+// cache at path (plus a path+".etag" sidecar) so a caller like Crush can
+// poll cheaply: an unchanged catalog costs a single round trip that comes
+// back as 304, and the cached body is decoded without ever touching the
+// network body. The cache's etag is derived from the cached bytes with
+// Etag, the same formula the server uses, so it doesn't need the server's
+// response header to stay in sync.
+func (c *Client) GetProvidersCached(ctx context.Context, path string) ([]Provider, error) {
+	providers, err := c.GetProviders(ctx, etag.ReadSidecar(path))
+	if errors.Is(err, ErrNotModified) {
+		data, rerr := os.ReadFile(path)
+		if rerr != nil {
+			return nil, fmt.Errorf("reading cached providers: %w", rerr)
+		}
+		var cached []Provider
+		if err := json.Unmarshal(data, &cached); err != nil {
+			return nil, fmt.Errorf("decoding cached providers: %w", err)
+		}
+		return cached, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	var providers []Provider
-	if err := json.NewDecoder(resp.Body).Decode(&providers); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	data, err := json.Marshal(providers)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling providers for cache: %w", err)
+	}
+	if _, err := (etag.FileStore{Path: path}).WriteIfChanged(data); err != nil {
+		return nil, fmt.Errorf("writing providers cache: %w", err)
+	}
+	if err := etag.WriteSidecar(path, Etag(data)); err != nil {
+		return nil, fmt.Errorf("writing providers cache etag: %w", err)
 	}
 
 	return providers, nil
 }
+
+// getWithRetry performs the GET, retrying per c.retryPolicy (via httpx.Do)
+// on transport errors or retryable status codes. A nil, nil return means
+// "304 Not Modified". It returns an open body on success; the caller is
+// responsible for closing it.
+func (c *Client) getWithRetry(ctx context.Context, url, etagValue string) (io.ReadCloser, error) {
+	resp, _, err := httpx.Do(ctx, c.httpClient, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not create request: %w", err)
+		}
+		if etagValue != "" {
+			// It needs to be quoted:
+			req.Header.Add("If-None-Match", fmt.Sprintf(`"%s"`, etagValue))
+		}
+		return req, nil
+	}, c.retryPolicy.toHTTPX())
+	if err != nil {
+		var statusErr *httpx.StatusError
+		if errors.As(err, &statusErr) {
+			return nil, &HTTPError{StatusCode: statusErr.StatusCode, Body: statusErr.Body, Attempts: statusErr.Attempts, Reason: statusErr.Reason}
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close() //nolint:errcheck
+		return nil, nil
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,73 @@
+package catwalk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/providers/openai" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"openai","name":"OpenAI"}`))
+	}))
+	defer srv.Close()
+
+	c := NewWithURL(srv.URL)
+	p, err := c.GetProvider(context.Background(), "openai", "")
+	if err != nil {
+		t.Fatalf("GetProvider() error = %v", err)
+	}
+	if p.ID != "openai" || p.Name != "OpenAI" {
+		t.Errorf("GetProvider() = %+v, want id=openai name=OpenAI", p)
+	}
+}
+
+func TestGetProvidersCachedReusesDiskOn304(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 1 {
+			t.Fatalf("expected only one round trip to reach the network, got %d", requests)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"openai","name":"OpenAI"}]`))
+	}))
+	defer srv.Close()
+
+	cachePath := filepath.Join(t.TempDir(), "providers.json")
+	c := NewWithURL(srv.URL)
+
+	first, err := c.GetProvidersCached(context.Background(), cachePath)
+	if err != nil {
+		t.Fatalf("GetProvidersCached() first call error = %v", err)
+	}
+	if len(first) != 1 || first[0].ID != "openai" {
+		t.Fatalf("GetProvidersCached() first call = %+v", first)
+	}
+	if _, err := os.Stat(cachePath); err != nil {
+		t.Fatalf("expected cache file to be written: %v", err)
+	}
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if match := r.Header.Get("If-None-Match"); match == "" {
+			t.Error("expected If-None-Match on the second request")
+		}
+		w.WriteHeader(http.StatusNotModified)
+	})
+
+	second, err := c.GetProvidersCached(context.Background(), cachePath)
+	if err != nil {
+		t.Fatalf("GetProvidersCached() second call error = %v", err)
+	}
+	if len(second) != 1 || second[0].ID != "openai" {
+		t.Errorf("GetProvidersCached() second call = %+v, want the cached body", second)
+	}
+}
@@ -0,0 +1,199 @@
+// Package configdiff computes structured diffs between two versions of a
+// catwalk.Provider, so regenerating a provider's config JSON can report what
+// actually changed instead of silently overwriting the file.
+package configdiff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+// PricingChange describes a per-model cost change, with the percent change
+// relative to the old value.
+type PricingChange struct {
+	ModelID       string  `json:"model_id"`
+	Field         string  `json:"field"`
+	Old           float64 `json:"old"`
+	New           float64 `json:"new"`
+	PercentChange float64 `json:"percent_change"`
+}
+
+// ContextWindowChange describes a model's context window changing size.
+type ContextWindowChange struct {
+	ModelID string `json:"model_id"`
+	Old     int64  `json:"old"`
+	New     int64  `json:"new"`
+}
+
+// CapabilityFlip describes a boolean capability flag (CanReason,
+// SupportsImages, ...) flipping value for a model.
+type CapabilityFlip struct {
+	ModelID string `json:"model_id"`
+	Field   string `json:"field"`
+	Old     bool   `json:"old"`
+	New     bool   `json:"new"`
+}
+
+// DefaultModelChange describes DefaultLargeModelID/DefaultSmallModelID being
+// reassigned.
+type DefaultModelChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Diff is the full set of differences between two Provider snapshots.
+type Diff struct {
+	ModelsAdded          []string              `json:"models_added,omitempty"`
+	ModelsRemoved        []string              `json:"models_removed,omitempty"`
+	PricingChanges       []PricingChange       `json:"pricing_changes,omitempty"`
+	ContextWindowChanges []ContextWindowChange `json:"context_window_changes,omitempty"`
+	CapabilityFlips      []CapabilityFlip      `json:"capability_flips,omitempty"`
+	DefaultModelChanges  []DefaultModelChange  `json:"default_model_changes,omitempty"`
+}
+
+// IsEmpty reports whether the diff contains no changes at all.
+func (d Diff) IsEmpty() bool {
+	return len(d.ModelsAdded) == 0 && len(d.ModelsRemoved) == 0 &&
+		len(d.PricingChanges) == 0 && len(d.ContextWindowChanges) == 0 &&
+		len(d.CapabilityFlips) == 0 && len(d.DefaultModelChanges) == 0
+}
+
+// String renders a human-readable summary of the diff, one change per line.
+func (d Diff) String() string {
+	if d.IsEmpty() {
+		return "no changes"
+	}
+
+	var b strings.Builder
+	for _, id := range d.ModelsAdded {
+		fmt.Fprintf(&b, "+ model added: %s\n", id)
+	}
+	for _, id := range d.ModelsRemoved {
+		fmt.Fprintf(&b, "- model removed: %s\n", id)
+	}
+	for _, c := range d.PricingChanges {
+		fmt.Fprintf(&b, "~ %s %s: %.4f -> %.4f (%+.1f%%)\n", c.ModelID, c.Field, c.Old, c.New, c.PercentChange)
+	}
+	for _, c := range d.ContextWindowChanges {
+		fmt.Fprintf(&b, "~ %s context_window: %d -> %d\n", c.ModelID, c.Old, c.New)
+	}
+	for _, c := range d.CapabilityFlips {
+		fmt.Fprintf(&b, "~ %s %s: %v -> %v\n", c.ModelID, c.Field, c.Old, c.New)
+	}
+	for _, c := range d.DefaultModelChanges {
+		fmt.Fprintf(&b, "~ %s: %s -> %s\n", c.Field, c.Old, c.New)
+	}
+	return b.String()
+}
+
+// Compute returns the Diff between an old and new Provider snapshot.
+func Compute(old, newP catwalk.Provider) Diff {
+	var diff Diff
+
+	oldModels := make(map[string]catwalk.Model, len(old.Models))
+	for _, m := range old.Models {
+		oldModels[m.ID] = m
+	}
+	newModels := make(map[string]catwalk.Model, len(newP.Models))
+	for _, m := range newP.Models {
+		newModels[m.ID] = m
+	}
+
+	for id := range newModels {
+		if _, ok := oldModels[id]; !ok {
+			diff.ModelsAdded = append(diff.ModelsAdded, id)
+		}
+	}
+	for id := range oldModels {
+		if _, ok := newModels[id]; !ok {
+			diff.ModelsRemoved = append(diff.ModelsRemoved, id)
+		}
+	}
+
+	for id, newM := range newModels {
+		oldM, ok := oldModels[id]
+		if !ok {
+			continue
+		}
+
+		diff.PricingChanges = append(diff.PricingChanges, pricingChanges(id, oldM, newM)...)
+
+		if oldM.ContextWindow != newM.ContextWindow {
+			diff.ContextWindowChanges = append(diff.ContextWindowChanges, ContextWindowChange{
+				ModelID: id, Old: oldM.ContextWindow, New: newM.ContextWindow,
+			})
+		}
+
+		if oldM.CanReason != newM.CanReason {
+			diff.CapabilityFlips = append(diff.CapabilityFlips, CapabilityFlip{ModelID: id, Field: "can_reason", Old: oldM.CanReason, New: newM.CanReason})
+		}
+		if oldM.SupportsImages != newM.SupportsImages {
+			diff.CapabilityFlips = append(diff.CapabilityFlips, CapabilityFlip{ModelID: id, Field: "supports_attachments", Old: oldM.SupportsImages, New: newM.SupportsImages})
+		}
+	}
+
+	if old.DefaultLargeModelID != newP.DefaultLargeModelID {
+		diff.DefaultModelChanges = append(diff.DefaultModelChanges, DefaultModelChange{Field: "default_large_model_id", Old: old.DefaultLargeModelID, New: newP.DefaultLargeModelID})
+	}
+	if old.DefaultSmallModelID != newP.DefaultSmallModelID {
+		diff.DefaultModelChanges = append(diff.DefaultModelChanges, DefaultModelChange{Field: "default_small_model_id", Old: old.DefaultSmallModelID, New: newP.DefaultSmallModelID})
+	}
+
+	return diff
+}
+
+func pricingChanges(id string, old, newM catwalk.Model) []PricingChange {
+	var changes []PricingChange
+	fields := []struct {
+		name     string
+		old, new float64
+	}{
+		{"cost_per_1m_in", old.CostPer1MIn, newM.CostPer1MIn},
+		{"cost_per_1m_out", old.CostPer1MOut, newM.CostPer1MOut},
+		{"cost_per_1m_in_cached", old.CostPer1MInCached, newM.CostPer1MInCached},
+		{"cost_per_1m_out_cached", old.CostPer1MOutCached, newM.CostPer1MOutCached},
+	}
+	for tier := range mergedCacheTiers(old.CachePricing, newM.CachePricing) {
+		o, n := old.CachePricing[tier], newM.CachePricing[tier]
+		fields = append(fields,
+			struct {
+				name     string
+				old, new float64
+			}{fmt.Sprintf("cache_pricing.%s.read_per_1m", tier), o.ReadPer1M, n.ReadPer1M},
+			struct {
+				name     string
+				old, new float64
+			}{fmt.Sprintf("cache_pricing.%s.write_per_1m", tier), o.WritePer1M, n.WritePer1M},
+		)
+	}
+
+	for _, f := range fields {
+		if f.old == f.new {
+			continue
+		}
+		percent := 0.0
+		if f.old != 0 {
+			percent = (f.new - f.old) / f.old * 100
+		}
+		changes = append(changes, PricingChange{ModelID: id, Field: f.name, Old: f.old, New: f.new, PercentChange: percent})
+	}
+
+	return changes
+}
+
+// mergedCacheTiers returns the union of tier labels present in either
+// CachePricing map, so pricingChanges can diff a tier that was added or
+// removed entirely, not just one whose price changed.
+func mergedCacheTiers(old, newC catwalk.CachePricing) map[string]struct{} {
+	tiers := make(map[string]struct{}, len(old)+len(newC))
+	for tier := range old {
+		tiers[tier] = struct{}{}
+	}
+	for tier := range newC {
+		tiers[tier] = struct{}{}
+	}
+	return tiers
+}
@@ -0,0 +1,55 @@
+package configdiff
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+func TestComputeDetectsChanges(t *testing.T) {
+	old := catwalk.Provider{
+		DefaultLargeModelID: "a",
+		Models: []catwalk.Model{
+			{ID: "a", CostPer1MIn: 1.0, ContextWindow: 100000, CanReason: false},
+			{ID: "b", CostPer1MIn: 2.0},
+		},
+	}
+	newP := catwalk.Provider{
+		DefaultLargeModelID: "c",
+		Models: []catwalk.Model{
+			{ID: "a", CostPer1MIn: 2.0, ContextWindow: 200000, CanReason: true},
+			{ID: "c", CostPer1MIn: 3.0},
+		},
+	}
+
+	diff := Compute(old, newP)
+
+	if diff.IsEmpty() {
+		t.Fatal("expected diff to report changes")
+	}
+	if len(diff.ModelsAdded) != 1 || diff.ModelsAdded[0] != "c" {
+		t.Errorf("expected model c added, got %v", diff.ModelsAdded)
+	}
+	if len(diff.ModelsRemoved) != 1 || diff.ModelsRemoved[0] != "b" {
+		t.Errorf("expected model b removed, got %v", diff.ModelsRemoved)
+	}
+	if len(diff.PricingChanges) != 1 || diff.PricingChanges[0].PercentChange != 100 {
+		t.Errorf("expected 100%% pricing increase for model a, got %+v", diff.PricingChanges)
+	}
+	if len(diff.ContextWindowChanges) != 1 {
+		t.Errorf("expected one context window change, got %+v", diff.ContextWindowChanges)
+	}
+	if len(diff.CapabilityFlips) != 1 {
+		t.Errorf("expected one capability flip, got %+v", diff.CapabilityFlips)
+	}
+	if len(diff.DefaultModelChanges) != 1 {
+		t.Errorf("expected default large model change, got %+v", diff.DefaultModelChanges)
+	}
+}
+
+func TestComputeNoChanges(t *testing.T) {
+	p := catwalk.Provider{Models: []catwalk.Model{{ID: "a", CostPer1MIn: 1.0}}}
+	if diff := Compute(p, p); !diff.IsEmpty() {
+		t.Errorf("expected no diff comparing a provider to itself, got %+v", diff)
+	}
+}
@@ -0,0 +1,116 @@
+package configdiff
+
+import "fmt"
+
+// RiskCategory identifies the kind of change Risks considers too dangerous
+// to land without a human looking at it.
+type RiskCategory string
+
+// All the risk categories Risks can report.
+const (
+	RiskModelRemoved         RiskCategory = "model_removed"
+	RiskPriceJump            RiskCategory = "price_jump"
+	RiskPriceZeroed          RiskCategory = "price_zeroed"
+	RiskContextShrink        RiskCategory = "context_shrink"
+	RiskCapabilityRegression RiskCategory = "capability_regression"
+)
+
+// Risk is a single change in a Diff flagged as risky.
+type Risk struct {
+	Category RiskCategory `json:"category"`
+	ModelID  string       `json:"model_id,omitempty"`
+	Message  string       `json:"message"`
+}
+
+// RiskThresholds configures how aggressively Risks flags pricing changes.
+// The zero value is replaced with DefaultRiskThresholds.
+type RiskThresholds struct {
+	// MaxPriceIncreasePercent is the largest per-model cost increase, as a
+	// percentage of the old value, Risks tolerates before flagging it.
+	MaxPriceIncreasePercent float64
+}
+
+// DefaultRiskThresholds flags any price increase over 25%.
+var DefaultRiskThresholds = RiskThresholds{MaxPriceIncreasePercent: 25}
+
+// Risks returns every change in d risky enough to gate a PR on: a model
+// removed, a price increasing by more than thresholds allows, a nonzero
+// price dropping all the way to zero (usually an upstream API mistake
+// rather than a real discount), a context window shrinking, or a
+// capability (can_reason, supports_attachments) regressing from true to
+// false. A capability being newly gained, a price dropping but staying
+// nonzero, or a context window growing are never risky.
+func (d Diff) Risks(thresholds RiskThresholds) []Risk {
+	if thresholds == (RiskThresholds{}) {
+		thresholds = DefaultRiskThresholds
+	}
+
+	var risks []Risk
+	for _, id := range d.ModelsRemoved {
+		risks = append(risks, Risk{
+			Category: RiskModelRemoved,
+			ModelID:  id,
+			Message:  fmt.Sprintf("model %q removed", id),
+		})
+	}
+
+	for _, c := range d.PricingChanges {
+		switch {
+		case c.Old != 0 && c.New == 0:
+			risks = append(risks, Risk{
+				Category: RiskPriceZeroed,
+				ModelID:  c.ModelID,
+				Message:  fmt.Sprintf("%s %s dropped to zero (%.4f -> %.4f)", c.ModelID, c.Field, c.Old, c.New),
+			})
+		case c.PercentChange > thresholds.MaxPriceIncreasePercent:
+			risks = append(risks, Risk{
+				Category: RiskPriceJump,
+				ModelID:  c.ModelID,
+				Message:  fmt.Sprintf("%s %s increased %.1f%% (%.4f -> %.4f)", c.ModelID, c.Field, c.PercentChange, c.Old, c.New),
+			})
+		}
+	}
+
+	for _, c := range d.ContextWindowChanges {
+		if c.New < c.Old {
+			risks = append(risks, Risk{
+				Category: RiskContextShrink,
+				ModelID:  c.ModelID,
+				Message:  fmt.Sprintf("%s context_window shrank %d -> %d", c.ModelID, c.Old, c.New),
+			})
+		}
+	}
+
+	for _, c := range d.CapabilityFlips {
+		if c.Old && !c.New {
+			risks = append(risks, Risk{
+				Category: RiskCapabilityRegression,
+				ModelID:  c.ModelID,
+				Message:  fmt.Sprintf("%s %s regressed true -> false", c.ModelID, c.Field),
+			})
+		}
+	}
+
+	return risks
+}
+
+// Unallowed returns the subset of risks whose Category isn't in allowed,
+// so a caller can fail only on risks the operator hasn't explicitly
+// acknowledged (e.g. via a repeatable --allow flag).
+func Unallowed(risks []Risk, allowed []RiskCategory) []Risk {
+	if len(allowed) == 0 {
+		return risks
+	}
+	allow := make(map[RiskCategory]bool, len(allowed))
+	for _, c := range allowed {
+		allow[c] = true
+	}
+
+	var out []Risk
+	for _, r := range risks {
+		if !allow[r.Category] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
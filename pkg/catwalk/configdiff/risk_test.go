@@ -0,0 +1,91 @@
+package configdiff
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+func TestRisksFlagsRemovalsPriceJumpsAndRegressions(t *testing.T) {
+	old := catwalk.Provider{
+		Models: []catwalk.Model{
+			{ID: "a", CostPer1MIn: 1.0, ContextWindow: 200000, CanReason: true},
+			{ID: "b", CostPer1MIn: 1.0, ContextWindow: 100000},
+		},
+	}
+	newP := catwalk.Provider{
+		Models: []catwalk.Model{
+			{ID: "a", CostPer1MIn: 2.0, ContextWindow: 100000, CanReason: false},
+		},
+	}
+
+	risks := Compute(old, newP).Risks(RiskThresholds{})
+
+	var categories []RiskCategory
+	for _, r := range risks {
+		categories = append(categories, r.Category)
+	}
+
+	for _, want := range []RiskCategory{RiskModelRemoved, RiskPriceJump, RiskContextShrink, RiskCapabilityRegression} {
+		found := false
+		for _, c := range categories {
+			if c == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected a %s risk, got %v", want, categories)
+		}
+	}
+}
+
+func TestRisksIgnoresImprovements(t *testing.T) {
+	old := catwalk.Provider{
+		Models: []catwalk.Model{{ID: "a", CostPer1MIn: 2.0, ContextWindow: 100000, CanReason: false}},
+	}
+	newP := catwalk.Provider{
+		Models: []catwalk.Model{{ID: "a", CostPer1MIn: 1.0, ContextWindow: 200000, CanReason: true}},
+	}
+
+	risks := Compute(old, newP).Risks(RiskThresholds{})
+	if len(risks) != 0 {
+		t.Errorf("expected no risks for an all-improvement diff, got %+v", risks)
+	}
+}
+
+func TestRisksFlagsPriceZeroedOut(t *testing.T) {
+	old := catwalk.Provider{
+		Models: []catwalk.Model{{ID: "a", CostPer1MIn: 5.0}},
+	}
+	newP := catwalk.Provider{
+		Models: []catwalk.Model{{ID: "a", CostPer1MIn: 0}},
+	}
+
+	risks := Compute(old, newP).Risks(RiskThresholds{})
+
+	found := false
+	for _, r := range risks {
+		if r.Category == RiskPriceZeroed {
+			found = true
+		}
+		if r.Category == RiskPriceJump {
+			t.Errorf("expected only price_zeroed, also got a price_jump risk: %+v", r)
+		}
+	}
+	if !found {
+		t.Errorf("expected a %s risk, got %v", RiskPriceZeroed, risks)
+	}
+}
+
+func TestUnallowedFiltersAcknowledgedCategories(t *testing.T) {
+	risks := []Risk{
+		{Category: RiskModelRemoved, ModelID: "a"},
+		{Category: RiskPriceJump, ModelID: "b"},
+	}
+
+	got := Unallowed(risks, []RiskCategory{RiskModelRemoved})
+	if len(got) != 1 || got[0].Category != RiskPriceJump {
+		t.Errorf("Unallowed() = %+v, want only the price_jump risk", got)
+	}
+}
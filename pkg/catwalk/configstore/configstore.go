@@ -0,0 +1,198 @@
+// Package configstore reads and writes catwalk.Provider configs in either
+// JSON or YAML, so hand-authored community providers don't have to be
+// edited as a single large JSON blob. Both formats decode to and encode
+// from the same catwalk.Provider/catwalk.Model types, using the yaml tags
+// added alongside their existing json ones.
+package configstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies a config file's encoding.
+type Format string
+
+// All the supported config formats.
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// FormatFromPath infers a Format from path's extension. It treats ".yml" and
+// ".yaml" as FormatYAML and everything else as FormatJSON.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// DecodeProvider reads a single catwalk.Provider from r in the given format.
+func DecodeProvider(r io.Reader, format Format) (catwalk.Provider, error) {
+	var provider catwalk.Provider
+	if err := decode(r, format, &provider); err != nil {
+		return catwalk.Provider{}, fmt.Errorf("decoding provider: %w", err)
+	}
+	return provider, nil
+}
+
+// EncodeProvider writes provider to w in the given format.
+func EncodeProvider(w io.Writer, provider catwalk.Provider, format Format) error {
+	if err := encode(w, format, provider); err != nil {
+		return fmt.Errorf("encoding provider: %w", err)
+	}
+	return nil
+}
+
+// DecodeProviders reads a monorepo-style file containing a list of
+// catwalk.Provider (the "providers.yaml" shape) from r.
+func DecodeProviders(r io.Reader, format Format) ([]catwalk.Provider, error) {
+	var providerList []catwalk.Provider
+	if err := decode(r, format, &providerList); err != nil {
+		return nil, fmt.Errorf("decoding providers: %w", err)
+	}
+	return providerList, nil
+}
+
+// EncodeProviders writes providerList to w in the given format.
+func EncodeProviders(w io.Writer, providerList []catwalk.Provider, format Format) error {
+	if err := encode(w, format, providerList); err != nil {
+		return fmt.Errorf("encoding providers: %w", err)
+	}
+	return nil
+}
+
+// LoadProviderFile reads and decodes a single-provider config file, inferring
+// its format from path's extension.
+func LoadProviderFile(path string) (catwalk.Provider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return catwalk.Provider{}, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	return DecodeProvider(f, FormatFromPath(path))
+}
+
+// SaveProviderFile encodes provider and writes it to path, inferring its
+// format from path's extension.
+func SaveProviderFile(path string, provider catwalk.Provider) error {
+	data, err := marshal(FormatFromPath(path), provider)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadProvidersFile reads and decodes a monorepo-style "providers.yaml" (or
+// .json) file holding a list of providers.
+func LoadProvidersFile(path string) ([]catwalk.Provider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	return DecodeProviders(f, FormatFromPath(path))
+}
+
+// SaveProvidersFile encodes providerList and writes it to path, inferring
+// its format from path's extension.
+func SaveProvidersFile(path string, providerList []catwalk.Provider) error {
+	data, err := marshal(FormatFromPath(path), providerList)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate checks that provider is well-formed enough to serve: it has a
+// name, an ID, at least one model, unique model IDs, and (via
+// catwalk.Provider.ValidateRoles) only references models that exist.
+func Validate(provider catwalk.Provider) error {
+	if provider.Name == "" {
+		return fmt.Errorf("provider: name is required")
+	}
+	if provider.ID == "" {
+		return fmt.Errorf("provider %q: id is required", provider.Name)
+	}
+	if len(provider.Models) == 0 {
+		return fmt.Errorf("provider %q: at least one model is required", provider.ID)
+	}
+
+	seen := make(map[string]bool, len(provider.Models))
+	for _, m := range provider.Models {
+		if m.ID == "" {
+			return fmt.Errorf("provider %q: model with empty id", provider.ID)
+		}
+		if seen[m.ID] {
+			return fmt.Errorf("provider %q: duplicate model id %q", provider.ID, m.ID)
+		}
+		seen[m.ID] = true
+
+		if (m.CostPer1MInCached > 0 || m.CostPer1MOutCached > 0) && !m.Capabilities.SupportsPromptCaching {
+			return fmt.Errorf("provider %q: model %q has cached pricing but Capabilities.SupportsPromptCaching is false", provider.ID, m.ID)
+		}
+	}
+
+	if err := provider.ValidateRoles(); err != nil {
+		return fmt.Errorf("provider %q: %w", provider.ID, err)
+	}
+
+	return nil
+}
+
+func decode(r io.Reader, format Format, v any) error {
+	switch format {
+	case FormatYAML:
+		return yaml.NewDecoder(r).Decode(v) //nolint:wrapcheck
+	default:
+		return json.NewDecoder(r).Decode(v) //nolint:wrapcheck
+	}
+}
+
+func encode(w io.Writer, format Format, v any) error {
+	switch format {
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		defer enc.Close() //nolint:errcheck
+		return enc.Encode(v) //nolint:wrapcheck
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v) //nolint:wrapcheck
+	}
+}
+
+func marshal(format Format, v any) ([]byte, error) {
+	switch format {
+	case FormatYAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling: %w", err)
+		}
+		return data, nil
+	default:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshaling: %w", err)
+		}
+		return data, nil
+	}
+}
@@ -0,0 +1,23 @@
+package catwalk
+
+import "fmt"
+
+// HTTPError is returned when a request to the catwalk service fails after
+// exhausting retries, or receives a non-retryable non-2xx status.
+type HTTPError struct {
+	// StatusCode is the last HTTP status code received.
+	StatusCode int
+	// Body is the last response body, truncated to a reasonable size.
+	Body string
+	// Attempts is how many requests were made.
+	Attempts int
+	// Reason describes why retrying stopped (e.g. "non-retryable status" or
+	// "max attempts exhausted").
+	Reason string
+}
+
+// Error implements error.
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("catwalk: request failed with status %d after %d attempt(s): %s: %s",
+		e.StatusCode, e.Attempts, e.Reason, e.Body)
+}
@@ -0,0 +1,30 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+)
+
+// RunCLI drives the standard per-provider generator CLI: --diff/--check
+// flags, a Generator.Run, and the --check/ErrDriftDetected exit-code
+// convention. Every cmd/<provider> main() used to hand-roll this block
+// identically; label (e.g. "Vercel") only affects the fatal error message.
+func RunCLI(source Source, label string) {
+	diff := flag.Bool("diff", false, "print a structured diff against the existing config instead of just overwriting it")
+	check := flag.Bool("check", false, "exit non-zero if the fetched models differ from the existing config, without writing")
+	flag.Parse()
+
+	gen := NewGenerator()
+	gen.Diff = *diff
+	gen.Check = *check
+
+	if _, err := gen.Run(context.Background(), source); err != nil {
+		if *check && errors.Is(err, ErrDriftDetected) {
+			os.Exit(1)
+		}
+		log.Fatalf("Error generating %s provider config: %v", label, err)
+	}
+}
@@ -0,0 +1,93 @@
+package fetcher
+
+import "github.com/charmbracelet/catwalk/pkg/catwalk"
+
+// Picker computes a provider's default large/small model IDs from its
+// final, sorted model list. It's the shape DefaultPicker.PickDefaults
+// expects, and what the strategies below (LargestContext, Cheapest, ByTag)
+// return, so a Source typically just forwards to one of them.
+type Picker func(models []catwalk.Model) (large, small string)
+
+// LargestContext picks the model with the largest context window (ties
+// broken by the highest output cost) as the large default, and the
+// cheapest combined input+output cost (ties broken by the smallest context
+// window) as the small default. This is the heuristic most hand-rolled
+// generators used before Picker existed.
+func LargestContext() Picker {
+	return func(models []catwalk.Model) (large, small string) {
+		return bestByContext(models), cheapest(models)
+	}
+}
+
+// Cheapest picks the single cheapest model (by combined input+output cost)
+// as both the large and small default, for catalogs with no clear "large"
+// tier.
+func Cheapest() Picker {
+	return func(models []catwalk.Model) (large, small string) {
+		id := cheapest(models)
+		return id, id
+	}
+}
+
+// ByTag picks the first model matching isLarge as the large default and the
+// first matching isSmall as the small default, falling back to
+// LargestContext for whichever side has no match. catwalk.Model carries no
+// tags of its own, so isLarge/isSmall usually match on ID or name.
+func ByTag(isLarge, isSmall func(catwalk.Model) bool) Picker {
+	fallback := LargestContext()
+	return func(models []catwalk.Model) (large, small string) {
+		large, small = fallback(models)
+		for _, m := range models {
+			if isLarge(m) {
+				large = m.ID
+				break
+			}
+		}
+		for _, m := range models {
+			if isSmall(m) {
+				small = m.ID
+				break
+			}
+		}
+		return large, small
+	}
+}
+
+func bestByContext(models []catwalk.Model) string {
+	var best *catwalk.Model
+	for i := range models {
+		m := &models[i]
+		switch {
+		case best == nil:
+			best = m
+		case m.ContextWindow > best.ContextWindow:
+			best = m
+		case m.ContextWindow == best.ContextWindow && m.CostPer1MOut > best.CostPer1MOut:
+			best = m
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return best.ID
+}
+
+func cheapest(models []catwalk.Model) string {
+	var best *catwalk.Model
+	for i := range models {
+		m := &models[i]
+		cost := m.CostPer1MIn + m.CostPer1MOut
+		switch {
+		case best == nil:
+			best = m
+		case cost < best.CostPer1MIn+best.CostPer1MOut:
+			best = m
+		case cost == best.CostPer1MIn+best.CostPer1MOut && m.ContextWindow < best.ContextWindow:
+			best = m
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return best.ID
+}
@@ -0,0 +1,336 @@
+// Package fetcher provides a shared driver for the provider-config
+// generator tools under cmd/. Each generator used to hand-roll its own HTTP
+// client, decode loop, filtering, sorting and file-writing; Generator
+// centralizes that so a new provider adapter only needs to implement Source.
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/catwalk/internal/etag"
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/configdiff"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/httpx"
+)
+
+// ErrDriftDetected is returned by Run when Generator.Check is set and the
+// freshly fetched provider differs from the config file already on disk.
+var ErrDriftDetected = fmt.Errorf("fetcher: unexpected drift between upstream and existing config")
+
+// RawModel is an upstream model entry, still in the source's own shape.
+// Source implementations type-assert Data back to their concrete type in
+// Normalize.
+type RawModel struct {
+	ID   string
+	Data any
+}
+
+// Source adapts a single upstream provider API to the Generator driver.
+type Source interface {
+	// ProviderID identifies the provider, used as the output filename
+	// (internal/providers/configs/<id>.json).
+	ProviderID() catwalk.InferenceProvider
+
+	// Provider returns the base provider metadata that normalized models are
+	// attached to.
+	Provider() catwalk.Provider
+
+	// Endpoint is the URL Fetch will GET. Generator takes care of the HTTP
+	// client, headers and retries.
+	Endpoint() string
+
+	// Decode turns the raw HTTP response body into RawModels.
+	Decode(body io.Reader) ([]RawModel, error)
+
+	// Normalize converts a single RawModel into a catwalk.Model. ok=false
+	// skips the model (wrong modality, missing tool support, etc).
+	Normalize(RawModel) (model catwalk.Model, ok bool)
+}
+
+// HeaderSource is an optional extension to Source for upstreams that need
+// more than the User-Agent Generator sets by default, most commonly an
+// Authorization bearer token.
+type HeaderSource interface {
+	Headers() map[string]string
+}
+
+// DefaultPicker is an optional extension to Source. A Source that
+// implements it has PickDefaults called once the provider's final model
+// list is known (normalized, deduped and sorted), so
+// DefaultLargeModelID/DefaultSmallModelID are derived from the fetched
+// catalog instead of being hardcoded in Provider(). See the Picker
+// strategies (LargestContext, Cheapest, ByTag) in defaults.go.
+type DefaultPicker interface {
+	PickDefaults(models []catwalk.Model) (large, small string)
+}
+
+// RoleAssigner is an optional extension to Source. A Source that implements
+// it has AssignRoles called once the provider's final model list is known
+// (normalized, deduped and sorted), so it can populate Provider.Roles from
+// the complete set instead of guessing model-by-model in Normalize.
+type RoleAssigner interface {
+	AssignRoles(catwalk.Provider) catwalk.Provider
+}
+
+// Generator drives a Source through fetch, decode, normalize, dedupe, sort
+// and write.
+type Generator struct {
+	HTTPClient *http.Client
+	OutputDir  string
+
+	// RetryPolicy controls backoff/jitter and which statuses are retried
+	// when fetching from a Source's Endpoint. See httpx.Do.
+	RetryPolicy httpx.Policy
+
+	// Diff, when set, prints a configdiff summary of what changed against
+	// the config file already on disk before writing the new one.
+	Diff bool
+	// Check, when set, makes Run return ErrDriftDetected (without writing)
+	// if the freshly fetched provider differs from the file on disk.
+	Check bool
+}
+
+// NewGenerator returns a Generator with sane defaults: a 30s-timeout HTTP
+// client, httpx.DefaultPolicy retries, writing to internal/providers/configs.
+func NewGenerator() *Generator {
+	return &Generator{
+		HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+		RetryPolicy: httpx.DefaultPolicy,
+		OutputDir:   filepath.Join("internal", "providers", "configs"),
+	}
+}
+
+// Run fetches, normalizes and writes the config file for source. It returns
+// the final provider so callers (tests, --diff flags in later tooling) can
+// inspect what was written.
+//
+// Before hitting the network it sends the provider's last-seen ETag (read
+// from a path+".etag" sidecar) as If-None-Match. Upstreams that honor it
+// (OpenRouter does) can answer 304, letting Run skip decode/normalize/write
+// entirely.
+func (g *Generator) Run(ctx context.Context, source Source) (catwalk.Provider, error) {
+	path := filepath.Join(g.OutputDir, string(source.ProviderID())+".json")
+	prevETag := etag.ReadSidecar(path)
+
+	provider, respETag, notModified, err := g.FetchProvider(ctx, source, prevETag)
+	if err != nil {
+		return catwalk.Provider{}, err
+	}
+	if notModified {
+		fmt.Printf("%s: upstream reports no change (304), skipping\n", source.ProviderID())
+		return readExisting(path)
+	}
+
+	if g.Diff || g.Check {
+		changed, err := g.reportDiff(path, provider)
+		if err != nil {
+			return catwalk.Provider{}, err
+		}
+		if g.Check && changed {
+			return provider, ErrDriftDetected
+		}
+	}
+
+	changed, err := g.write(path, provider)
+	if err != nil {
+		return catwalk.Provider{}, err
+	}
+	if changed && respETag != "" {
+		if err := etag.WriteSidecar(path, respETag); err != nil {
+			return catwalk.Provider{}, err
+		}
+	}
+
+	return provider, nil
+}
+
+// FetchProvider runs the fetch/decode/normalize/dedupe/sort/role-assignment
+// pipeline for source and returns the resulting provider, without touching
+// disk. ifNoneMatch, when non-empty, is sent as If-None-Match; notModified
+// reports that the upstream answered 304, in which case provider is the
+// zero value and the caller should keep whatever it already has.
+//
+// Run uses this for its on-disk generator workflow; providers.Registry uses
+// it directly to refresh an in-memory snapshot without a config file on
+// either end.
+func (g *Generator) FetchProvider(ctx context.Context, source Source, ifNoneMatch string) (provider catwalk.Provider, respETag string, notModified bool, err error) {
+	var headers map[string]string
+	if hs, ok := source.(HeaderSource); ok {
+		headers = hs.Headers()
+	}
+
+	body, respETag, notModified, err := g.fetch(ctx, source.Endpoint(), ifNoneMatch, headers)
+	if err != nil {
+		return catwalk.Provider{}, "", false, fmt.Errorf("fetching %s: %w", source.ProviderID(), err)
+	}
+	if notModified {
+		return catwalk.Provider{}, respETag, true, nil
+	}
+	defer body.Close() //nolint:errcheck
+
+	raw, err := source.Decode(body)
+	if err != nil {
+		return catwalk.Provider{}, "", false, fmt.Errorf("decoding %s: %w", source.ProviderID(), err)
+	}
+
+	provider = source.Provider()
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		model, ok := source.Normalize(r)
+		if !ok {
+			continue
+		}
+		if seen[model.ID] {
+			continue
+		}
+		seen[model.ID] = true
+		provider.Models = append(provider.Models, model)
+	}
+
+	slices.SortFunc(provider.Models, func(a, b catwalk.Model) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if picker, ok := source.(DefaultPicker); ok {
+		provider.DefaultLargeModelID, provider.DefaultSmallModelID = picker.PickDefaults(provider.Models)
+	}
+
+	warnIfDefaultModelMissing(provider)
+
+	if assigner, ok := source.(RoleAssigner); ok {
+		provider = assigner.AssignRoles(provider)
+	}
+
+	return provider, respETag, false, nil
+}
+
+// fetch performs the HTTP GET, retrying per g.RetryPolicy (exponential
+// backoff with jitter, honoring Retry-After) on transport errors and
+// retryable statuses via httpx.Do. ifNoneMatch, when non-empty, is sent so
+// the upstream can answer 304 Not Modified; notModified reports that case,
+// in which body is nil and the caller should skip decode/normalize/write.
+// Otherwise body is the (already status-checked) response body and respETag
+// is the upstream's ETag response header, if any.
+func (g *Generator) fetch(ctx context.Context, url, ifNoneMatch string, headers map[string]string) (body io.ReadCloser, respETag string, notModified bool, err error) {
+	resp, summary, err := httpx.Do(ctx, g.HTTPClient, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		req.Header.Set("User-Agent", "Crush-Client/1.0")
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		return req, nil
+	}, g.RetryPolicy)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if summary.Attempts > 1 {
+		fmt.Printf("%s: fetched after %d attempts in %s (last status %d)\n", url, summary.Attempts, summary.Elapsed, summary.LastStatus)
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close() //nolint:errcheck
+		return nil, ifNoneMatch, true, nil
+	}
+
+	return resp.Body, resp.Header.Get("ETag"), false, nil
+}
+
+// write marshals provider and, if it differs from what's already at path,
+// atomically replaces path (so a crash mid-write can't leave a truncated
+// config behind). It reports whether a write happened.
+func (g *Generator) write(path string, provider catwalk.Provider) (bool, error) {
+	data, err := json.MarshalIndent(provider, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("marshaling %s provider: %w", provider.ID, err)
+	}
+
+	changed, err := (etag.FileStore{Path: path}).WriteIfChanged(data)
+	if err != nil {
+		return false, err
+	}
+	if !changed {
+		fmt.Printf("%s: no change\n", path)
+		return false, nil
+	}
+
+	fmt.Printf("Generated %s with %d models\n", path, len(provider.Models))
+	return true, nil
+}
+
+// readExisting loads the provider config already on disk at path, used when
+// an upstream 304 means there's nothing new to normalize or write.
+func readExisting(path string) (catwalk.Provider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return catwalk.Provider{}, fmt.Errorf("reading existing %s: %w", path, err)
+	}
+
+	var provider catwalk.Provider
+	if err := json.Unmarshal(data, &provider); err != nil {
+		return catwalk.Provider{}, fmt.Errorf("parsing existing %s: %w", path, err)
+	}
+	return provider, nil
+}
+
+// reportDiff compares provider against the config file already on disk (if
+// any) and prints a configdiff summary when Diff is set. It returns whether
+// any change was detected.
+func (g *Generator) reportDiff(path string, provider catwalk.Provider) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		if g.Diff {
+			fmt.Printf("%s: no existing config, nothing to diff\n", path)
+		}
+		return len(provider.Models) > 0, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading existing %s: %w", path, err)
+	}
+
+	var old catwalk.Provider
+	if err := json.Unmarshal(data, &old); err != nil {
+		return false, fmt.Errorf("parsing existing %s: %w", path, err)
+	}
+
+	diff := configdiff.Compute(old, provider)
+	if g.Diff {
+		fmt.Printf("%s diff:\n%s", path, diff.String())
+	}
+	return !diff.IsEmpty(), nil
+}
+
+// warnIfDefaultModelMissing logs (doesn't fail) when a provider's configured
+// default large/small model ID doesn't actually appear in the final model
+// list, which usually means the upstream renamed or retired it.
+func warnIfDefaultModelMissing(provider catwalk.Provider) {
+	has := func(id string) bool {
+		for _, m := range provider.Models {
+			if m.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	if provider.DefaultLargeModelID != "" && !has(provider.DefaultLargeModelID) {
+		fmt.Printf("warning: %s default large model %q not found in fetched models\n", provider.ID, provider.DefaultLargeModelID)
+	}
+	if provider.DefaultSmallModelID != "" && !has(provider.DefaultSmallModelID) {
+		fmt.Printf("warning: %s default small model %q not found in fetched models\n", provider.ID, provider.DefaultSmallModelID)
+	}
+}
@@ -0,0 +1,61 @@
+package fetcher
+
+import (
+	"strconv"
+)
+
+// MinContextWindow reports whether window clears the threshold a Source's
+// Normalize uses to drop models too small to be worth surfacing (chat
+// history doesn't fit, so Crush would just thrash). Every hand-rolled
+// generator before this duplicated its own "skip if context window < N"
+// constant; centralizing it here keeps that threshold a single knob per
+// call site instead of a magic number buried in each Normalize.
+func MinContextWindow(window, threshold int64) bool {
+	return window >= threshold
+}
+
+// ReasoningDefaults fills in the ReasoningLevels/DefaultReasoningEffort pair
+// a Normalize typically derives from a single "can this model reason?"
+// flag: levels if canReason, nothing otherwise. Sources with a richer
+// upstream taxonomy (explicit low/medium/high support, say) can still set
+// these fields directly instead of calling this.
+func ReasoningDefaults(canReason bool) (levels []string, def string) {
+	if !canReason {
+		return nil, ""
+	}
+	return []string{"low", "medium", "high"}, "medium"
+}
+
+// ParsePer1M parses an upstream per-token price string (as Vercel, OpenRouter
+// and friends return it) and scales it to catwalk's per-million-token
+// convention. An empty or unparseable s is treated as free (0), since
+// upstreams usually omit the field rather than send a literal zero.
+func ParsePer1M(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v * 1_000_000
+}
+
+// ClampMaxTokens caps tokens at max, leaving it unchanged if max <= 0 (no
+// cap) or tokens is already within bounds.
+func ClampMaxTokens(tokens, max int64) int64 {
+	if max > 0 && tokens > max {
+		return max
+	}
+	return tokens
+}
+
+// DefaultMaxTokensFromContext derives a conservative DefaultMaxTokens from a
+// model's context window, for upstreams that don't report a max completion
+// size of their own: a fraction of the window (1/divisor), capped at ceiling.
+func DefaultMaxTokensFromContext(contextWindow, divisor, ceiling int64) int64 {
+	if divisor <= 0 {
+		divisor = 1
+	}
+	return ClampMaxTokens(contextWindow/divisor, ceiling)
+}
@@ -0,0 +1,34 @@
+package fetcher
+
+import "testing"
+
+func TestMinContextWindow(t *testing.T) {
+	tests := []struct {
+		window, threshold int64
+		want              bool
+	}{
+		{20000, 20000, true},
+		{19999, 20000, false},
+		{1_000_000, 20000, true},
+	}
+	for _, tt := range tests {
+		if got := MinContextWindow(tt.window, tt.threshold); got != tt.want {
+			t.Errorf("MinContextWindow(%d, %d) = %v, want %v", tt.window, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestReasoningDefaults(t *testing.T) {
+	levels, def := ReasoningDefaults(false)
+	if levels != nil || def != "" {
+		t.Errorf("ReasoningDefaults(false) = %v, %q, want nil, \"\"", levels, def)
+	}
+
+	levels, def = ReasoningDefaults(true)
+	if def != "medium" {
+		t.Errorf("ReasoningDefaults(true) default = %q, want %q", def, "medium")
+	}
+	if len(levels) != 3 {
+		t.Errorf("ReasoningDefaults(true) levels = %v, want 3 entries", levels)
+	}
+}
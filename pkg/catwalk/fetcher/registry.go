@@ -0,0 +1,72 @@
+package fetcher
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+// registry is the process-wide set of Sources registered via Register,
+// keyed by Source.ProviderID. cmd/catwalk-gen iterates it instead of each
+// provider needing its own entry in that binary's main(); generator
+// packages populate it from an init() so importing one for its side effect
+// is enough to make it known.
+var registry = struct {
+	mu      sync.Mutex
+	sources map[catwalk.InferenceProvider]Source
+}{sources: make(map[catwalk.InferenceProvider]Source)}
+
+// Register adds source to the shared Registry, keyed by its ProviderID.
+// Generator packages call this from an init(), so a blank import
+// (`_ "internal/providers/generators/vercel"`) is all cmd/catwalk-gen needs
+// to pick it up. Register panics on a duplicate ProviderID, since that can
+// only happen from a programming error (two packages claiming the same
+// provider), never from user input.
+func Register(source Source) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	id := source.ProviderID()
+	if _, exists := registry.sources[id]; exists {
+		panic(fmt.Sprintf("fetcher: %s already registered", id))
+	}
+	registry.sources[id] = source
+}
+
+// Registered returns every Source registered so far, sorted by ProviderID
+// for deterministic iteration order.
+func Registered() []Source {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	sources := make([]Source, 0, len(registry.sources))
+	for _, source := range registry.sources {
+		sources = append(sources, source)
+	}
+	slices.SortFunc(sources, func(a, b Source) int {
+		return compareProviderID(a.ProviderID(), b.ProviderID())
+	})
+	return sources
+}
+
+// Lookup returns the Source registered under id, or ok=false if none is.
+func Lookup(id catwalk.InferenceProvider) (source Source, ok bool) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	source, ok = registry.sources[id]
+	return source, ok
+}
+
+func compareProviderID(a, b catwalk.InferenceProvider) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,141 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalkpb"
+	"google.golang.org/grpc"
+)
+
+// Client adapts a pkg/catwalkpb.ProviderCatalogServiceClient to the same
+// Providers/Subscribe shape as pkg/catwalkpb.Registry, so it can stand in
+// anywhere an in-process registry is read from.
+type Client struct {
+	conn   *grpc.ClientConn
+	client catwalkpb.ProviderCatalogServiceClient
+}
+
+// Dial opens a gRPC connection to target (e.g. "catwalk.example.com:8081")
+// and returns a Client backed by it. Callers typically pass
+// grpc.WithTransportCredentials(insecure.NewCredentials()) for a
+// plaintext/dev connection, or proper TLS credentials in production.
+func Dial(target string, opts ...grpc.DialOption) (*Client, error) {
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("dialing %s: %w", target, err)
+	}
+	return &Client{conn: conn, client: catwalkpb.NewProviderCatalogServiceClient(conn)}, nil
+}
+
+// Close closes the underlying gRPC connection.
+func (c *Client) Close() error {
+	return c.conn.Close() //nolint:wrapcheck
+}
+
+// Providers returns the remote catalog's full provider list.
+func (c *Client) Providers(ctx context.Context) ([]catwalk.Provider, error) {
+	resp, err := c.client.ListProviders(ctx, &catwalkpb.ListProvidersRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("listing providers: %w", err)
+	}
+
+	providers := make([]catwalk.Provider, 0, len(resp.GetProviders()))
+	for _, p := range resp.GetProviders() {
+		providers = append(providers, catwalkpb.ToProvider(p))
+	}
+	return providers, nil
+}
+
+// Provider returns a single provider by ID.
+func (c *Client) Provider(ctx context.Context, id catwalk.InferenceProvider) (catwalk.Provider, error) {
+	resp, err := c.client.GetProvider(ctx, &catwalkpb.GetProviderRequest{Id: string(id)})
+	if err != nil {
+		return catwalk.Provider{}, fmt.Errorf("getting provider %s: %w", id, err)
+	}
+	return catwalkpb.ToProvider(resp.GetProvider()), nil
+}
+
+// Models returns every model the remote catalog serves, or just
+// providerID's models when it's non-empty.
+func (c *Client) Models(ctx context.Context, providerID catwalk.InferenceProvider) ([]catwalk.Model, error) {
+	resp, err := c.client.ListModels(ctx, &catwalkpb.ListModelsRequest{ProviderId: string(providerID)})
+	if err != nil {
+		return nil, fmt.Errorf("listing models: %w", err)
+	}
+
+	models := make([]catwalk.Model, 0, len(resp.GetModels()))
+	for _, m := range resp.GetModels() {
+		models = append(models, catwalkpb.ToModel(m))
+	}
+	return models, nil
+}
+
+// Subscribe streams the remote catalog once on connect and again every time
+// it changes, until ctx is done or the stream ends, mirroring
+// pkg/catwalkpb.Registry.Subscribe's contract.
+func (c *Client) Subscribe(ctx context.Context) <-chan []catwalk.Provider {
+	ch := make(chan []catwalk.Provider, 1)
+
+	go func() {
+		defer close(ch)
+
+		stream, err := c.client.WatchProviders(ctx, &catwalkpb.WatchProvidersRequest{})
+		if err != nil {
+			return
+		}
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				return
+			}
+
+			providers := make([]catwalk.Provider, 0, len(resp.GetProviders()))
+			for _, p := range resp.GetProviders() {
+				providers = append(providers, catwalkpb.ToProvider(p))
+			}
+
+			select {
+			case ch <- providers:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// RegisterProvider advertises provider to the remote catalog for as long as
+// ctx stays alive, resending provider (via updates, if ever needed) on the
+// same stream; the registration is dropped as soon as ctx is done or the
+// stream errors. The returned channel receives one RegisterProviderResponse
+// per accepted registration, mainly useful for logging/observability.
+func (c *Client) RegisterProvider(ctx context.Context, provider catwalk.Provider) (<-chan *catwalkpb.RegisterProviderResponse, error) {
+	stream, err := c.client.RegisterProvider(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("opening RegisterProvider stream: %w", err)
+	}
+	if err := stream.Send(&catwalkpb.RegisterProviderRequest{Provider: catwalkpb.FromProvider(provider)}); err != nil {
+		return nil, fmt.Errorf("sending provider registration: %w", err)
+	}
+
+	acks := make(chan *catwalkpb.RegisterProviderResponse, 1)
+	go func() {
+		defer close(acks)
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case acks <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return acks, nil
+}
@@ -0,0 +1,11 @@
+// Package grpcclient is a pkg/catwalkpb.ProviderCatalogService client that
+// exposes the same Providers/Subscribe reader shape as
+// *internal/providers.Registry (see pkg/catwalkpb.Registry), so code that
+// reads from the embedded/local provider catalog can be pointed at a
+// remote catwalk-server instead without changing how it reads the result.
+//
+// catwalk.pb.go and catwalk_grpc.pb.go are generated from
+// pkg/catwalkpb/catwalk.proto and aren't checked into the repo (see
+// pkg/catwalkpb's doc.go); run `buf generate pkg/catwalkpb` before building
+// anything that imports this package.
+package grpcclient
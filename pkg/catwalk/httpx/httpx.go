@@ -0,0 +1,212 @@
+// Package httpx is the shared retry/backoff driver behind every outbound
+// HTTP call this module makes, whether that's catwalk.Client polling the
+// catwalk service or a provider-config generator under cmd/ hitting an
+// upstream API. Before this existed, each had grown its own copy of
+// "sleep, retry on 5xx/429, honor Retry-After" with slightly different
+// knobs; Policy/Do centralizes it so a flaky upstream degrades the same way
+// everywhere.
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Policy configures Do's retry behavior.
+type Policy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value <= 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt.
+	BaseDelay time.Duration
+	// Factor is the multiplier applied to the delay after each attempt
+	// (2 doubles it every time, matching the package doc's "exponential").
+	Factor float64
+	// MaxDelay caps the computed backoff, before jitter and before any
+	// Retry-After override.
+	MaxDelay time.Duration
+	// RetryableStatus lists HTTP status codes that should be retried. 5xx
+	// and 429 are retried by DefaultPolicy.
+	RetryableStatus []int
+}
+
+// DefaultPolicy retries up to 5 attempts total, starting at a 500ms
+// backoff that doubles (capped at 30s) with jitter, on network errors and
+// 429/5xx responses.
+var DefaultPolicy = Policy{
+	MaxAttempts:     5,
+	BaseDelay:       500 * time.Millisecond,
+	Factor:          2,
+	MaxDelay:        30 * time.Second,
+	RetryableStatus: []int{http.StatusTooManyRequests, 500, 502, 503, 504},
+}
+
+func (p Policy) isRetryable(status int) bool {
+	for _, s := range p.RetryableStatus {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary reports what Do actually did, so a caller can log one structured
+// line (e.g. "zen: fetched in 2 attempts, 840ms, last status 200") instead
+// of leaving CI to guess whether an upstream was flaky or just broken.
+type Summary struct {
+	Attempts   int
+	LastStatus int
+	Elapsed    time.Duration
+}
+
+// StatusError is returned by Do when every attempt fails, or the first
+// non-retryable status is received.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	Attempts   int
+	// Reason describes why retrying stopped ("non-retryable status" or "max
+	// attempts exhausted").
+	Reason string
+}
+
+// Error implements error.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("httpx: request failed with status %d after %d attempt(s): %s: %s",
+		e.StatusCode, e.Attempts, e.Reason, e.Body)
+}
+
+// newRequest builds the request to send for a single attempt. Do calls this
+// fresh on every attempt instead of reusing one *http.Request, since a
+// request with a non-nil Body can only be sent once.
+type newRequest func(ctx context.Context) (*http.Request, error)
+
+// Do runs newRequest and client.Do, retrying per policy on transport errors
+// and retryable statuses, honoring ctx cancellation/deadlines between
+// attempts and any Retry-After header on a retryable response. On success
+// it returns the open response; the caller is responsible for closing its
+// body. On failure it returns a *StatusError (a non-retryable or
+// retries-exhausted status) or the last transport error.
+func Do(ctx context.Context, client *http.Client, newReq newRequest, policy Policy) (*http.Response, Summary, error) {
+	start := nowFunc()
+	maxAttempts := max(policy.MaxAttempts, 1)
+
+	var lastErr error
+	var lastStatus int
+	var lastBody string
+	var skipBackoff bool
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 && !skipBackoff {
+			if err := sleepWithContext(ctx, backoffDelay(policy, attempt-1)); err != nil {
+				return nil, Summary{Attempts: attempt - 1, LastStatus: lastStatus, Elapsed: nowFunc().Sub(start)}, err
+			}
+		}
+		skipBackoff = false
+
+		req, err := newReq(ctx)
+		if err != nil {
+			return nil, Summary{}, fmt.Errorf("building request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		// 304 Not Modified is a terminal success for a conditional GET, not
+		// a status to retry or fail on: the caller (e.g. Client.getWithRetry)
+		// needs to see it to short-circuit to its on-disk cache.
+		if resp.StatusCode < 300 || resp.StatusCode == http.StatusNotModified {
+			return resp, Summary{Attempts: attempt, LastStatus: resp.StatusCode, Elapsed: nowFunc().Sub(start)}, nil
+		}
+
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close() //nolint:errcheck
+		lastStatus = resp.StatusCode
+		lastBody = string(b)
+		summary := Summary{Attempts: attempt, LastStatus: lastStatus, Elapsed: nowFunc().Sub(start)}
+
+		if !policy.isRetryable(resp.StatusCode) {
+			return nil, summary, &StatusError{StatusCode: lastStatus, Body: lastBody, Attempts: attempt, Reason: "non-retryable status"}
+		}
+
+		if retryAfter := retryAfterDelay(resp.Header.Get("Retry-After")); retryAfter > 0 {
+			if err := sleepWithContext(ctx, retryAfter); err != nil {
+				return nil, summary, err
+			}
+			// Retry-After already waited the server's requested delay;
+			// don't also sleep the next iteration's exponential backoff.
+			skipBackoff = true
+		}
+		lastErr = fmt.Errorf("status %d: %s", lastStatus, lastBody)
+	}
+
+	summary := Summary{Attempts: maxAttempts, LastStatus: lastStatus, Elapsed: nowFunc().Sub(start)}
+	if lastStatus != 0 {
+		return nil, summary, &StatusError{StatusCode: lastStatus, Body: lastBody, Attempts: maxAttempts, Reason: "max attempts exhausted"}
+	}
+	return nil, summary, fmt.Errorf("failed to make request after %d attempt(s): %w", maxAttempts, lastErr)
+}
+
+// nowFunc is a var so tests can stub out elapsed-time measurement.
+var nowFunc = time.Now
+
+// backoffDelay returns the exponential backoff (capped at MaxDelay) with up
+// to 20% jitter for the given retry number (1 = delay before 2nd attempt).
+func backoffDelay(policy Policy, retry int) time.Duration {
+	factor := policy.Factor
+	if factor <= 0 {
+		factor = 2
+	}
+	delay := float64(policy.BaseDelay)
+	for range retry - 1 {
+		delay *= factor
+	}
+	d := time.Duration(delay)
+	if policy.MaxDelay > 0 && d > policy.MaxDelay {
+		d = policy.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(d)/5 + 1)) //nolint:gosec
+	return d + jitter
+}
+
+// retryAfterDelay parses a Retry-After header, in either of the two forms
+// RFC 9110 allows: a number of seconds, or an HTTP-date to wait until.
+// Empty, malformed, or past-dated values return 0 (no extra delay).
+func retryAfterDelay(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	var seconds int
+	if _, err := fmt.Sscanf(header, "%d", &seconds); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepWithContext waits for d, returning early with ctx.Err() if ctx is
+// cancelled or its deadline expires first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDoRetriesOnRetryableStatus(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	policy := Policy{MaxAttempts: 5, BaseDelay: time.Millisecond, Factor: 2, MaxDelay: 10 * time.Millisecond, RetryableStatus: DefaultPolicy.RetryableStatus}
+
+	resp, summary, err := Do(context.Background(), srv.Client(), func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	}, policy)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close() //nolint:errcheck
+
+	if summary.Attempts != 3 {
+		t.Errorf("Attempts = %d, want 3", summary.Attempts)
+	}
+	if summary.LastStatus != http.StatusOK {
+		t.Errorf("LastStatus = %d, want 200", summary.LastStatus)
+	}
+}
+
+func TestDoReturnsStatusErrorOnNonRetryable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, _, err := Do(context.Background(), srv.Client(), func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	}, DefaultPolicy)
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("Do() error = %v (%T), want *StatusError", err, err)
+	}
+	if statusErr.StatusCode != http.StatusNotFound || statusErr.Attempts != 1 {
+		t.Errorf("got %+v, want status 404 after 1 attempt", statusErr)
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	if d := retryAfterDelay(""); d != 0 {
+		t.Errorf("empty header: got %v, want 0", d)
+	}
+	if d := retryAfterDelay("5"); d != 5*time.Second {
+		t.Errorf("seconds form: got %v, want 5s", d)
+	}
+	future := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if d := retryAfterDelay(future); d <= 0 || d > 10*time.Second {
+		t.Errorf("HTTP-date form: got %v, want (0, 10s]", d)
+	}
+	past := time.Now().Add(-10 * time.Second).UTC().Format(http.TimeFormat)
+	if d := retryAfterDelay(past); d != 0 {
+		t.Errorf("past HTTP-date: got %v, want 0", d)
+	}
+}
@@ -0,0 +1,63 @@
+package catwalk
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk/httpx"
+)
+
+// RetryPolicy configures how Client retries failed requests.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value <= 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles on each
+	// subsequent attempt (capped at MaxDelay) and gets up to 20% jitter.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay between attempts.
+	MaxDelay time.Duration
+	// RetryableStatus lists HTTP status codes that should be retried. 5xx
+	// and 429 are retried by default.
+	RetryableStatus []int
+}
+
+// defaultRetryPolicy is used by New/NewWithURL unless WithRetry overrides it.
+var defaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     3,
+	BaseDelay:       250 * time.Millisecond,
+	MaxDelay:        5 * time.Second,
+	RetryableStatus: []int{http.StatusTooManyRequests, 500, 502, 503, 504},
+}
+
+// toHTTPX converts p to the httpx.Policy getWithRetry actually drives,
+// defaulting Factor to 2 (plain doubling) since RetryPolicy predates
+// httpx and has no such knob of its own.
+func (p RetryPolicy) toHTTPX() httpx.Policy {
+	return httpx.Policy{
+		MaxAttempts:     p.MaxAttempts,
+		BaseDelay:       p.BaseDelay,
+		Factor:          2,
+		MaxDelay:        p.MaxDelay,
+		RetryableStatus: p.RetryableStatus,
+	}
+}
+
+// Option configures a Client constructed by New or NewWithURL.
+type Option func(*Client)
+
+// WithHTTPClient overrides the *http.Client used to make requests.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithTimeout sets the overall per-request timeout on the Client's HTTP
+// client.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.httpClient.Timeout = d }
+}
+
+// WithRetry overrides the Client's RetryPolicy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) { c.retryPolicy = policy }
+}
@@ -1,5 +1,11 @@
 package catwalk
 
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
 // Type represents the type of AI provider.
 type Type string
 
@@ -45,42 +51,445 @@ const (
 
 // Provider represents an AI provider configuration.
 type Provider struct {
-	Name                string            `json:"name"`
-	ID                  InferenceProvider `json:"id"`
-	APIKey              string            `json:"api_key,omitempty"`
-	APIEndpoint         string            `json:"api_endpoint,omitempty"`
-	Type                Type              `json:"type,omitempty"`
-	DefaultLargeModelID string            `json:"default_large_model_id,omitempty"`
-	DefaultSmallModelID string            `json:"default_small_model_id,omitempty"`
-	Models              []Model           `json:"models,omitempty"`
-	DefaultHeaders      map[string]string `json:"default_headers,omitempty"`
+	Name                string            `json:"name" yaml:"name"`
+	ID                  InferenceProvider `json:"id" yaml:"id"`
+	APIKey              string            `json:"api_key,omitempty" yaml:"api_key,omitempty"`
+	APIEndpoint         string            `json:"api_endpoint,omitempty" yaml:"api_endpoint,omitempty"`
+	Type                Type              `json:"type,omitempty" yaml:"type,omitempty"`
+	DefaultLargeModelID string            `json:"default_large_model_id,omitempty" yaml:"default_large_model_id,omitempty"`
+	DefaultSmallModelID string            `json:"default_small_model_id,omitempty" yaml:"default_small_model_id,omitempty"`
+	Models              []Model           `json:"models,omitempty" yaml:"models,omitempty"`
+	DefaultHeaders      map[string]string `json:"default_headers,omitempty" yaml:"default_headers,omitempty"`
+
+	// Roles maps an auxiliary task (title generation, summarization, ...) to
+	// the ID of the model in Models best suited for it, so clients don't
+	// have to hardcode per-provider model IDs for anything beyond the main
+	// chat default. See ValidateRoles.
+	Roles map[ModelRole]string `json:"roles,omitempty" yaml:"roles,omitempty"`
+}
+
+// ModelRole identifies an auxiliary task a provider can delegate to a
+// specific model, distinct from DefaultLargeModelID/DefaultSmallModelID.
+type ModelRole string
+
+// All the supported model roles.
+const (
+	RoleTitle          ModelRole = "title"
+	RoleSummary        ModelRole = "summary"
+	RoleEmbedding      ModelRole = "embedding"
+	RoleVisionFallback ModelRole = "vision_fallback"
+	RoleReasoning      ModelRole = "reasoning"
+)
+
+// ValidateRoles returns an error if any entry in p.Roles references a model
+// ID that isn't present in p.Models.
+func (p Provider) ValidateRoles() error {
+	for role, id := range p.Roles {
+		found := false
+		for _, m := range p.Models {
+			if m.ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("role %q references unknown model %q", role, id)
+		}
+	}
+	return nil
 }
 
 // ModelOptions stores extra options for models.
 type ModelOptions struct {
-	Temperature      *float64       `json:"temperature,omitempty"`
-	TopP             *float64       `json:"top_p,omitempty"`
-	TopK             *int64         `json:"top_k,omitempty"`
-	FrequencyPenalty *float64       `json:"frequency_penalty,omitempty"`
-	PresencePenalty  *float64       `json:"presence_penalty,omitempty"`
-	ProviderOptions  map[string]any `json:"provider_options,omitempty"`
+	Temperature      *float64       `json:"temperature,omitempty" yaml:"temperature,omitempty"`
+	TopP             *float64       `json:"top_p,omitempty" yaml:"top_p,omitempty"`
+	TopK             *int64         `json:"top_k,omitempty" yaml:"top_k,omitempty"`
+	FrequencyPenalty *float64       `json:"frequency_penalty,omitempty" yaml:"frequency_penalty,omitempty"`
+	PresencePenalty  *float64       `json:"presence_penalty,omitempty" yaml:"presence_penalty,omitempty"`
+	ProviderOptions  map[string]any `json:"provider_options,omitempty" yaml:"provider_options,omitempty"`
+}
+
+// Kind identifies what a Model is used for. Most providers only expose chat
+// models, but some also serve embedding, image-generation or transcription
+// endpoints that don't fit the chat-completion shape.
+type Kind string
+
+// All the supported model kinds. KindChat is the zero value so existing
+// configs that don't set "kind" keep working.
+const (
+	KindChat          Kind = "chat"
+	KindEmbedding     Kind = "embedding"
+	KindImage         Kind = "image"
+	KindTranscription Kind = "transcription"
+	KindTTS           Kind = "tts"
+)
+
+// CacheTierPricing holds the cost of reading an already-cached prompt prefix
+// and of writing a new one at a particular cache TTL.
+type CacheTierPricing struct {
+	ReadPer1M  float64 `json:"read_per_1m" yaml:"read_per_1m"`
+	WritePer1M float64 `json:"write_per_1m" yaml:"write_per_1m"`
+}
+
+// CachePricing maps a cache TTL label (e.g. "5m", "1h") to its tier pricing,
+// for providers whose prompt-caching cost varies by how long the cache entry
+// is kept (Anthropic-style ephemeral caching). Providers with a single
+// cached-cost tier can leave this unset and just populate
+// CostPer1MInCached/CostPer1MOutCached.
+type CachePricing map[string]CacheTierPricing
+
+// EmbeddingSpec holds capability metadata specific to KindEmbedding models.
+type EmbeddingSpec struct {
+	Dimensions     int64 `json:"dimensions,omitempty" yaml:"dimensions,omitempty"`
+	MaxInputTokens int64 `json:"max_input_tokens,omitempty" yaml:"max_input_tokens,omitempty"`
+}
+
+// ImageSpec holds capability metadata specific to KindImage models.
+type ImageSpec struct {
+	Sizes         []string `json:"sizes,omitempty" yaml:"sizes,omitempty"`
+	SupportsEdits bool     `json:"supports_edits,omitempty" yaml:"supports_edits,omitempty"`
+}
+
+// AudioSpec holds capability metadata specific to KindTTS and
+// KindTranscription models.
+type AudioSpec struct {
+	SampleRateHz int64 `json:"sample_rate_hz,omitempty" yaml:"sample_rate_hz,omitempty"`
+	// Voices is only meaningful for KindTTS models.
+	Voices []string `json:"voices,omitempty" yaml:"voices,omitempty"`
+}
+
+// ModelOffering is one inference provider's route to serve a Model: its own
+// InferenceProvider identity, the model ID/endpoint to address it with if
+// they differ from the canonical ones, its pricing, and the context length
+// it actually honors (providers often cap it lower than the model's true
+// ContextWindow). Routers that serve the same weights through more than one
+// provider (e.g. Hugging Face Router) populate Model.Offerings instead of
+// emitting a duplicate Model per provider.
+type ModelOffering struct {
+	InferenceProvider InferenceProvider `json:"inference_provider" yaml:"inference_provider"`
+	// ModelID overrides Model.ID as the ID to send this provider, for
+	// routers that address a specific provider via a provider-qualified
+	// model ID (e.g. Hugging Face Router's "owner/model:provider").
+	ModelID string `json:"model_id,omitempty" yaml:"model_id,omitempty"`
+	// APIEndpoint overrides the owning Provider.APIEndpoint for this
+	// offering specifically.
+	APIEndpoint        string  `json:"api_endpoint,omitempty" yaml:"api_endpoint,omitempty"`
+	ContextWindow      int64   `json:"context_window,omitempty" yaml:"context_window,omitempty"`
+	CostPer1MIn        float64 `json:"cost_per_1m_in" yaml:"cost_per_1m_in"`
+	CostPer1MOut       float64 `json:"cost_per_1m_out" yaml:"cost_per_1m_out"`
+	CostPer1MInCached  float64 `json:"cost_per_1m_in_cached" yaml:"cost_per_1m_in_cached"`
+	CostPer1MOutCached float64 `json:"cost_per_1m_out_cached" yaml:"cost_per_1m_out_cached"`
+}
+
+// Modality identifies a single input or output modality a model can handle,
+// for Capabilities.InputModalities/OutputModalities.
+type Modality string
+
+// All the supported modalities.
+const (
+	ModalityText      Modality = "text"
+	ModalityImage     Modality = "image"
+	ModalityAudio     Modality = "audio"
+	ModalityVideo     Modality = "video"
+	ModalityEmbedding Modality = "embedding"
+)
+
+// Capability identifies a single queryable model capability, for use with
+// Model.Supports and Registry.GetByCapability. Most correspond directly to a
+// Capabilities field; CapabilityReasoning and the per-modality capabilities
+// additionally fold in CanReason/SupportsImages so callers don't have to
+// know which flag predates Capabilities.
+type Capability string
+
+// All the supported capabilities.
+const (
+	CapabilityReasoning        Capability = "reasoning"
+	CapabilityTools            Capability = "tools"
+	CapabilityParallelTools    Capability = "parallel_tools"
+	CapabilityJSONMode         Capability = "json_mode"
+	CapabilityStructuredOutput Capability = "structured_output"
+	CapabilityPromptCaching    Capability = "prompt_caching"
+	CapabilityImageInput       Capability = "image_input"
+	CapabilityAudioInput       Capability = "audio_input"
+	CapabilityAudioOutput      Capability = "audio_output"
+	CapabilityVideoInput       Capability = "video_input"
+	CapabilityStreaming        Capability = "streaming"
+)
+
+// Capabilities holds the finer-grained capability flags a Model can
+// advertise beyond CanReason/SupportsImages, for callers (agents, routers)
+// that need to pick a model by what it can do -- tool calling, structured
+// output, a particular input/output modality -- instead of hand-coding
+// provider knowledge. Every field is the zero value ("unknown"/"no") for a
+// model config that predates Capabilities.
+type Capabilities struct {
+	SupportsTools            bool       `json:"supports_tools,omitempty" yaml:"supports_tools,omitempty"`
+	SupportsParallelTools    bool       `json:"supports_parallel_tools,omitempty" yaml:"supports_parallel_tools,omitempty"`
+	SupportsJSONMode         bool       `json:"supports_json_mode,omitempty" yaml:"supports_json_mode,omitempty"`
+	SupportsStructuredOutput bool       `json:"supports_structured_output,omitempty" yaml:"supports_structured_output,omitempty"`
+	SupportsPromptCaching    bool       `json:"supports_prompt_caching,omitempty" yaml:"supports_prompt_caching,omitempty"`
+	SupportsStreaming        bool       `json:"supports_streaming,omitempty" yaml:"supports_streaming,omitempty"`
+	InputModalities          []Modality `json:"input_modalities,omitempty" yaml:"input_modalities,omitempty"`
+	OutputModalities         []Modality `json:"output_modalities,omitempty" yaml:"output_modalities,omitempty"`
+	KnowledgeCutoff          time.Time  `json:"knowledge_cutoff,omitempty" yaml:"knowledge_cutoff,omitempty"`
+	MaxOutputTokens          int64      `json:"max_output_tokens,omitempty" yaml:"max_output_tokens,omitempty"`
 }
 
 // Model represents an AI model configuration.
 type Model struct {
-	ID                     string       `json:"id"`
-	Name                   string       `json:"name"`
-	CostPer1MIn            float64      `json:"cost_per_1m_in"`
-	CostPer1MOut           float64      `json:"cost_per_1m_out"`
-	CostPer1MInCached      float64      `json:"cost_per_1m_in_cached"`
-	CostPer1MOutCached     float64      `json:"cost_per_1m_out_cached"`
-	ContextWindow          int64        `json:"context_window"`
-	DefaultMaxTokens       int64        `json:"default_max_tokens"`
-	CanReason              bool         `json:"can_reason"`
-	ReasoningLevels        []string     `json:"reasoning_levels,omitempty"`
-	DefaultReasoningEffort string       `json:"default_reasoning_effort,omitempty"`
-	SupportsImages         bool         `json:"supports_attachments"`
-	Options                ModelOptions `json:"options"`
+	ID                     string       `json:"id" yaml:"id"`
+	Name                   string       `json:"name" yaml:"name"`
+	CostPer1MIn            float64      `json:"cost_per_1m_in" yaml:"cost_per_1m_in"`
+	CostPer1MOut           float64      `json:"cost_per_1m_out" yaml:"cost_per_1m_out"`
+	CostPer1MInCached      float64      `json:"cost_per_1m_in_cached" yaml:"cost_per_1m_in_cached"`
+	CostPer1MOutCached     float64      `json:"cost_per_1m_out_cached" yaml:"cost_per_1m_out_cached"`
+	ContextWindow          int64        `json:"context_window" yaml:"context_window"`
+	DefaultMaxTokens       int64        `json:"default_max_tokens" yaml:"default_max_tokens"`
+	CanReason              bool         `json:"can_reason" yaml:"can_reason"`
+	ReasoningLevels        []string     `json:"reasoning_levels,omitempty" yaml:"reasoning_levels,omitempty"`
+	DefaultReasoningEffort string       `json:"default_reasoning_effort,omitempty" yaml:"default_reasoning_effort,omitempty"`
+	SupportsImages         bool         `json:"supports_attachments" yaml:"supports_attachments"`
+	Options                ModelOptions `json:"options" yaml:"options"`
+
+	// CachePricing holds the full per-TTL cache cost breakdown, for
+	// providers that expose more than one cache tier. CostPer1MInCached/
+	// CostPer1MOutCached above should still be set to the tier callers
+	// should default to when they don't care about TTL.
+	CachePricing CachePricing `json:"cache_pricing,omitempty" yaml:"cache_pricing,omitempty"`
+
+	// Kind identifies what the model is used for. Empty/"" is treated as
+	// KindChat for backwards compatibility with existing configs.
+	Kind Kind `json:"kind,omitempty" yaml:"kind,omitempty"`
+	// Embedding is only populated when Kind == KindEmbedding.
+	Embedding *EmbeddingSpec `json:"embedding,omitempty" yaml:"embedding,omitempty"`
+	// Image is only populated when Kind == KindImage.
+	Image *ImageSpec `json:"image,omitempty" yaml:"image,omitempty"`
+	// Audio is only populated when Kind == KindTTS or KindTranscription.
+	Audio *AudioSpec `json:"audio,omitempty" yaml:"audio,omitempty"`
+
+	// Capabilities holds the finer-grained capability flags queryable
+	// through Supports, left zero-valued for configs that predate it.
+	Capabilities Capabilities `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+
+	// Offerings lists additional provider-specific routes for this model,
+	// for the same logical weights served through more than one inference
+	// provider. A model offered by only one provider leaves this nil, so
+	// its JSON stays the legacy flat shape built from the fields above.
+	Offerings []ModelOffering `json:"offerings,omitempty" yaml:"offerings,omitempty"`
+}
+
+// Supports reports whether m advertises cap, falling back to CanReason/
+// SupportsImages for CapabilityReasoning/CapabilityImageInput so a caller
+// doesn't need to special-case the fields that predate Capabilities.
+func (m Model) Supports(cap Capability) bool {
+	switch cap {
+	case CapabilityReasoning:
+		return m.CanReason
+	case CapabilityTools:
+		return m.Capabilities.SupportsTools
+	case CapabilityParallelTools:
+		return m.Capabilities.SupportsParallelTools
+	case CapabilityJSONMode:
+		return m.Capabilities.SupportsJSONMode
+	case CapabilityStructuredOutput:
+		return m.Capabilities.SupportsStructuredOutput
+	case CapabilityPromptCaching:
+		return m.Capabilities.SupportsPromptCaching
+	case CapabilityImageInput:
+		return m.SupportsImages || slices.Contains(m.Capabilities.InputModalities, ModalityImage)
+	case CapabilityAudioInput:
+		return slices.Contains(m.Capabilities.InputModalities, ModalityAudio)
+	case CapabilityAudioOutput:
+		return slices.Contains(m.Capabilities.OutputModalities, ModalityAudio)
+	case CapabilityVideoInput:
+		return slices.Contains(m.Capabilities.InputModalities, ModalityVideo)
+	case CapabilityStreaming:
+		return m.Capabilities.SupportsStreaming
+	default:
+		return false
+	}
+}
+
+// EffectiveKind returns m.Kind, defaulting to KindChat when unset.
+func (m Model) EffectiveKind() Kind {
+	if m.Kind == "" {
+		return KindChat
+	}
+	return m.Kind
+}
+
+// CheapestOffering returns the entry in m.Offerings with the lowest
+// CostPer1MIn, or ok=false if m has no Offerings.
+func (m Model) CheapestOffering() (offering ModelOffering, ok bool) {
+	for _, o := range m.Offerings {
+		if !ok || o.CostPer1MIn < offering.CostPer1MIn {
+			offering, ok = o, true
+		}
+	}
+	return offering, ok
+}
+
+// OfferingFor returns the entry in m.Offerings served by provider, or
+// ok=false if m isn't offered through it.
+func (m Model) OfferingFor(provider InferenceProvider) (offering ModelOffering, ok bool) {
+	for _, o := range m.Offerings {
+		if o.InferenceProvider == provider {
+			return o, true
+		}
+	}
+	return ModelOffering{}, false
+}
+
+// FilterOfferings returns the subset of m.Offerings for which filter
+// returns true, for callers that want to route/failover across providers on
+// some other criterion (context window, a price ceiling, etc.).
+func (m Model) FilterOfferings(filter func(ModelOffering) bool) []ModelOffering {
+	var result []ModelOffering
+	for _, o := range m.Offerings {
+		if filter(o) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// ModelsByKind returns the subset of p.Models with the given effective Kind.
+func (p Provider) ModelsByKind(kind Kind) []Model {
+	var models []Model
+	for _, m := range p.Models {
+		if m.EffectiveKind() == kind {
+			models = append(models, m)
+		}
+	}
+	return models
+}
+
+// ModelsWithCapability returns the subset of p.Models that support cap, per
+// Model.Supports.
+func (p Provider) ModelsWithCapability(cap Capability) []Model {
+	var models []Model
+	for _, m := range p.Models {
+		if m.Supports(cap) {
+			models = append(models, m)
+		}
+	}
+	return models
+}
+
+// Usage is a count of tokens consumed by a single request, broken out by the
+// pricing bucket they fall into, for Model.EstimateCost.
+type Usage struct {
+	InputTokens       int64
+	OutputTokens      int64
+	CachedInputTokens int64
+	CacheWriteTokens  int64
+}
+
+// Cost is the USD breakdown EstimateCost produces for a Usage, one field per
+// pricing bucket plus the Total a caller actually wants.
+type Cost struct {
+	Input       float64
+	Output      float64
+	CachedInput float64
+	CacheWrite  float64
+	Total       float64
+}
+
+// EstimateCost prices usage against m's per-1M-token rates. CachedInputTokens
+// are billed at CostPer1MInCached (a cache read) and CacheWriteTokens at
+// CostPer1MOutCached (a cache write), matching the mapping the Vercel
+// generator fills these fields with.
+func (m Model) EstimateCost(usage Usage) Cost {
+	in, out, inCached, outCached := m.NormalizedPricing()
+
+	c := Cost{
+		Input:       float64(usage.InputTokens) * in,
+		Output:      float64(usage.OutputTokens) * out,
+		CachedInput: float64(usage.CachedInputTokens) * inCached,
+		CacheWrite:  float64(usage.CacheWriteTokens) * outCached,
+	}
+	c.Total = c.Input + c.Output + c.CachedInput + c.CacheWrite
+	return c
+}
+
+// NormalizedPricing returns m's CostPer1MIn/Out/InCached/OutCached converted
+// to a per-token USD rate, so callers pricing a token count don't each redo
+// the ×1,000,000 math by hand.
+func (m Model) NormalizedPricing() (in, out, inCached, outCached float64) {
+	return m.CostPer1MIn / 1_000_000,
+		m.CostPer1MOut / 1_000_000,
+		m.CostPer1MInCached / 1_000_000,
+		m.CostPer1MOutCached / 1_000_000
+}
+
+// CompareCost orders a and b by their EstimateCost(expectedUsage).Total, for
+// use with slices.SortFunc when ranking models by real workload cost instead
+// of list price.
+func CompareCost(a, b Model, expectedUsage Usage) int {
+	costA := a.EstimateCost(expectedUsage).Total
+	costB := b.EstimateCost(expectedUsage).Total
+	switch {
+	case costA < costB:
+		return -1
+	case costA > costB:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CheapestModelFor returns the cheapest (by CostPer1MIn) model in p.Models
+// with at least ctxWindow of context that satisfies every capability caps
+// asks for, or nil if none qualify.
+func (p Provider) CheapestModelFor(ctxWindow int64, caps Capabilities) *Model {
+	var cheapest *Model
+	for i, m := range p.Models {
+		if m.ContextWindow < ctxWindow {
+			continue
+		}
+		if !satisfiesCapabilities(m.Capabilities, caps) {
+			continue
+		}
+		if cheapest == nil || m.CostPer1MIn < cheapest.CostPer1MIn {
+			cheapest = &p.Models[i]
+		}
+	}
+	return cheapest
+}
+
+// satisfiesCapabilities reports whether have covers everything want asks
+// for: every true bool flag in want is also true in have, and every
+// modality listed in want is present in have's corresponding list.
+func satisfiesCapabilities(have, want Capabilities) bool {
+	if want.SupportsTools && !have.SupportsTools {
+		return false
+	}
+	if want.SupportsParallelTools && !have.SupportsParallelTools {
+		return false
+	}
+	if want.SupportsJSONMode && !have.SupportsJSONMode {
+		return false
+	}
+	if want.SupportsStructuredOutput && !have.SupportsStructuredOutput {
+		return false
+	}
+	if want.SupportsPromptCaching && !have.SupportsPromptCaching {
+		return false
+	}
+	if want.SupportsStreaming && !have.SupportsStreaming {
+		return false
+	}
+	for _, modality := range want.InputModalities {
+		if !slices.Contains(have.InputModalities, modality) {
+			return false
+		}
+	}
+	for _, modality := range want.OutputModalities {
+		if !slices.Contains(have.OutputModalities, modality) {
+			return false
+		}
+	}
+	return true
 }
 
 // KnownProviders returns all the known inference providers.
@@ -0,0 +1,66 @@
+package catwalk
+
+import "testing"
+
+func TestModelEstimateCost(t *testing.T) {
+	m := Model{
+		CostPer1MIn:        3,
+		CostPer1MOut:       15,
+		CostPer1MInCached:  0.3,
+		CostPer1MOutCached: 3.75,
+	}
+
+	got := m.EstimateCost(Usage{
+		InputTokens:       1_000_000,
+		OutputTokens:      1_000_000,
+		CachedInputTokens: 1_000_000,
+		CacheWriteTokens:  1_000_000,
+	})
+
+	want := Cost{Input: 3, Output: 15, CachedInput: 0.3, CacheWrite: 3.75, Total: 22.05}
+	if got != want {
+		t.Errorf("EstimateCost() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCompareCost(t *testing.T) {
+	cheap := Model{CostPer1MIn: 1, CostPer1MOut: 1}
+	pricey := Model{CostPer1MIn: 10, CostPer1MOut: 10}
+	usage := Usage{InputTokens: 1000, OutputTokens: 1000}
+
+	if got := CompareCost(cheap, pricey, usage); got != -1 {
+		t.Errorf("CompareCost(cheap, pricey) = %d, want -1", got)
+	}
+	if got := CompareCost(pricey, cheap, usage); got != 1 {
+		t.Errorf("CompareCost(pricey, cheap) = %d, want 1", got)
+	}
+	if got := CompareCost(cheap, cheap, usage); got != 0 {
+		t.Errorf("CompareCost(cheap, cheap) = %d, want 0", got)
+	}
+}
+
+func TestProviderCheapestModelFor(t *testing.T) {
+	p := Provider{
+		Models: []Model{
+			{ID: "too-small", ContextWindow: 1000, CostPer1MIn: 1},
+			{ID: "no-tools", ContextWindow: 100_000, CostPer1MIn: 1},
+			{
+				ID: "cheap", ContextWindow: 100_000, CostPer1MIn: 2,
+				Capabilities: Capabilities{SupportsTools: true},
+			},
+			{
+				ID: "pricey", ContextWindow: 100_000, CostPer1MIn: 5,
+				Capabilities: Capabilities{SupportsTools: true},
+			},
+		},
+	}
+
+	got := p.CheapestModelFor(50_000, Capabilities{SupportsTools: true})
+	if got == nil || got.ID != "cheap" {
+		t.Errorf("CheapestModelFor() = %v, want model %q", got, "cheap")
+	}
+
+	if got := p.CheapestModelFor(50_000, Capabilities{InputModalities: []Modality{ModalityVideo}}); got != nil {
+		t.Errorf("CheapestModelFor() with unmet modality = %v, want nil", got)
+	}
+}
@@ -0,0 +1,283 @@
+package catwalkpb
+
+import (
+	"time"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+// FromProvider converts a catwalk.Provider to its protobuf representation.
+func FromProvider(p catwalk.Provider) *Provider {
+	models := make([]*Model, 0, len(p.Models))
+	for _, m := range p.Models {
+		models = append(models, FromModel(m))
+	}
+
+	var roles map[string]string
+	if len(p.Roles) > 0 {
+		roles = make(map[string]string, len(p.Roles))
+		for role, id := range p.Roles {
+			roles[string(role)] = id
+		}
+	}
+
+	return &Provider{
+		Name:                p.Name,
+		Id:                  string(p.ID),
+		ApiKey:              p.APIKey,
+		ApiEndpoint:         p.APIEndpoint,
+		Type:                string(p.Type),
+		DefaultLargeModelId: p.DefaultLargeModelID,
+		DefaultSmallModelId: p.DefaultSmallModelID,
+		Models:              models,
+		DefaultHeaders:      p.DefaultHeaders,
+		Roles:               roles,
+	}
+}
+
+// FromProviders converts a slice of catwalk.Provider to their protobuf
+// representation.
+func FromProviders(provs []catwalk.Provider) []*Provider {
+	out := make([]*Provider, 0, len(provs))
+	for _, p := range provs {
+		out = append(out, FromProvider(p))
+	}
+	return out
+}
+
+// FromModel converts a catwalk.Model to its protobuf representation.
+func FromModel(m catwalk.Model) *Model {
+	pb := &Model{
+		Id:                     m.ID,
+		Name:                   m.Name,
+		CostPer1MIn:            m.CostPer1MIn,
+		CostPer1MOut:           m.CostPer1MOut,
+		CostPer1MInCached:      m.CostPer1MInCached,
+		CostPer1MOutCached:     m.CostPer1MOutCached,
+		ContextWindow:          m.ContextWindow,
+		DefaultMaxTokens:       m.DefaultMaxTokens,
+		CanReason:              m.CanReason,
+		ReasoningLevels:        m.ReasoningLevels,
+		DefaultReasoningEffort: m.DefaultReasoningEffort,
+		SupportsImages:         m.SupportsImages,
+		Options: &ModelOptions{
+			Temperature:      m.Options.Temperature,
+			TopP:             m.Options.TopP,
+			TopK:             m.Options.TopK,
+			FrequencyPenalty: m.Options.FrequencyPenalty,
+			PresencePenalty:  m.Options.PresencePenalty,
+		},
+		Kind: string(m.Kind),
+	}
+
+	if len(m.CachePricing) > 0 {
+		pb.CachePricing = make(map[string]*CacheTierPricing, len(m.CachePricing))
+		for ttl, tier := range m.CachePricing {
+			pb.CachePricing[ttl] = &CacheTierPricing{
+				ReadPer1M:  tier.ReadPer1M,
+				WritePer1M: tier.WritePer1M,
+			}
+		}
+	}
+	if m.Embedding != nil {
+		pb.Embedding = &EmbeddingSpec{
+			Dimensions:     m.Embedding.Dimensions,
+			MaxInputTokens: m.Embedding.MaxInputTokens,
+		}
+	}
+	if m.Image != nil {
+		pb.Image = &ImageSpec{
+			Sizes:         m.Image.Sizes,
+			SupportsEdits: m.Image.SupportsEdits,
+		}
+	}
+	if m.Audio != nil {
+		pb.Audio = &AudioSpec{
+			SampleRateHz: m.Audio.SampleRateHz,
+			Voices:       m.Audio.Voices,
+		}
+	}
+
+	pb.Capabilities = &Capabilities{
+		SupportsTools:            m.Capabilities.SupportsTools,
+		SupportsParallelTools:    m.Capabilities.SupportsParallelTools,
+		SupportsJsonMode:         m.Capabilities.SupportsJSONMode,
+		SupportsStructuredOutput: m.Capabilities.SupportsStructuredOutput,
+		SupportsPromptCaching:    m.Capabilities.SupportsPromptCaching,
+		SupportsStreaming:        m.Capabilities.SupportsStreaming,
+		InputModalities:          modalitiesToStrings(m.Capabilities.InputModalities),
+		OutputModalities:         modalitiesToStrings(m.Capabilities.OutputModalities),
+		MaxOutputTokens:          m.Capabilities.MaxOutputTokens,
+	}
+	if !m.Capabilities.KnowledgeCutoff.IsZero() {
+		pb.Capabilities.KnowledgeCutoff = m.Capabilities.KnowledgeCutoff.Format(time.RFC3339)
+	}
+
+	if len(m.Offerings) > 0 {
+		pb.Offerings = make([]*ModelOffering, 0, len(m.Offerings))
+		for _, o := range m.Offerings {
+			pb.Offerings = append(pb.Offerings, &ModelOffering{
+				InferenceProvider:  string(o.InferenceProvider),
+				ModelId:            o.ModelID,
+				ApiEndpoint:        o.APIEndpoint,
+				ContextWindow:      o.ContextWindow,
+				CostPer1MIn:        o.CostPer1MIn,
+				CostPer1MOut:       o.CostPer1MOut,
+				CostPer1MInCached:  o.CostPer1MInCached,
+				CostPer1MOutCached: o.CostPer1MOutCached,
+			})
+		}
+	}
+
+	return pb
+}
+
+// modalitiesToStrings converts a slice of catwalk.Modality to the plain
+// strings the wire format carries.
+func modalitiesToStrings(modalities []catwalk.Modality) []string {
+	if len(modalities) == 0 {
+		return nil
+	}
+	out := make([]string, len(modalities))
+	for i, m := range modalities {
+		out[i] = string(m)
+	}
+	return out
+}
+
+// stringsToModalities is the inverse of modalitiesToStrings.
+func stringsToModalities(modalities []string) []catwalk.Modality {
+	if len(modalities) == 0 {
+		return nil
+	}
+	out := make([]catwalk.Modality, len(modalities))
+	for i, m := range modalities {
+		out[i] = catwalk.Modality(m)
+	}
+	return out
+}
+
+// ToProvider converts a protobuf Provider back to a catwalk.Provider, for
+// RegisterProvider: the one place this package receives a Provider from a
+// client rather than sending one.
+func ToProvider(p *Provider) catwalk.Provider {
+	models := make([]catwalk.Model, 0, len(p.GetModels()))
+	for _, m := range p.GetModels() {
+		models = append(models, ToModel(m))
+	}
+
+	var roles map[catwalk.ModelRole]string
+	if len(p.GetRoles()) > 0 {
+		roles = make(map[catwalk.ModelRole]string, len(p.GetRoles()))
+		for role, id := range p.GetRoles() {
+			roles[catwalk.ModelRole(role)] = id
+		}
+	}
+
+	return catwalk.Provider{
+		Name:                p.GetName(),
+		ID:                  catwalk.InferenceProvider(p.GetId()),
+		APIKey:              p.GetApiKey(),
+		APIEndpoint:         p.GetApiEndpoint(),
+		Type:                catwalk.Type(p.GetType()),
+		DefaultLargeModelID: p.GetDefaultLargeModelId(),
+		DefaultSmallModelID: p.GetDefaultSmallModelId(),
+		Models:              models,
+		DefaultHeaders:      p.GetDefaultHeaders(),
+		Roles:               roles,
+	}
+}
+
+// ToModel converts a protobuf Model back to a catwalk.Model.
+func ToModel(m *Model) catwalk.Model {
+	out := catwalk.Model{
+		ID:                     m.GetId(),
+		Name:                   m.GetName(),
+		CostPer1MIn:            m.GetCostPer1MIn(),
+		CostPer1MOut:           m.GetCostPer1MOut(),
+		CostPer1MInCached:      m.GetCostPer1MInCached(),
+		CostPer1MOutCached:     m.GetCostPer1MOutCached(),
+		ContextWindow:          m.GetContextWindow(),
+		DefaultMaxTokens:       m.GetDefaultMaxTokens(),
+		CanReason:              m.GetCanReason(),
+		ReasoningLevels:        m.GetReasoningLevels(),
+		DefaultReasoningEffort: m.GetDefaultReasoningEffort(),
+		SupportsImages:         m.GetSupportsImages(),
+		Kind:                   catwalk.Kind(m.GetKind()),
+	}
+
+	if opts := m.GetOptions(); opts != nil {
+		out.Options = catwalk.ModelOptions{
+			Temperature:      opts.Temperature,
+			TopP:             opts.TopP,
+			TopK:             opts.TopK,
+			FrequencyPenalty: opts.FrequencyPenalty,
+			PresencePenalty:  opts.PresencePenalty,
+		}
+	}
+
+	if len(m.GetCachePricing()) > 0 {
+		out.CachePricing = make(catwalk.CachePricing, len(m.GetCachePricing()))
+		for ttl, tier := range m.GetCachePricing() {
+			out.CachePricing[ttl] = catwalk.CacheTierPricing{
+				ReadPer1M:  tier.GetReadPer1M(),
+				WritePer1M: tier.GetWritePer1M(),
+			}
+		}
+	}
+	if e := m.GetEmbedding(); e != nil {
+		out.Embedding = &catwalk.EmbeddingSpec{
+			Dimensions:     e.GetDimensions(),
+			MaxInputTokens: e.GetMaxInputTokens(),
+		}
+	}
+	if i := m.GetImage(); i != nil {
+		out.Image = &catwalk.ImageSpec{
+			Sizes:         i.GetSizes(),
+			SupportsEdits: i.GetSupportsEdits(),
+		}
+	}
+	if a := m.GetAudio(); a != nil {
+		out.Audio = &catwalk.AudioSpec{
+			SampleRateHz: a.GetSampleRateHz(),
+			Voices:       a.GetVoices(),
+		}
+	}
+
+	if c := m.GetCapabilities(); c != nil {
+		out.Capabilities = catwalk.Capabilities{
+			SupportsTools:            c.GetSupportsTools(),
+			SupportsParallelTools:    c.GetSupportsParallelTools(),
+			SupportsJSONMode:         c.GetSupportsJsonMode(),
+			SupportsStructuredOutput: c.GetSupportsStructuredOutput(),
+			SupportsPromptCaching:    c.GetSupportsPromptCaching(),
+			SupportsStreaming:        c.GetSupportsStreaming(),
+			InputModalities:          stringsToModalities(c.GetInputModalities()),
+			OutputModalities:         stringsToModalities(c.GetOutputModalities()),
+			MaxOutputTokens:          c.GetMaxOutputTokens(),
+		}
+		if cutoff := c.GetKnowledgeCutoff(); cutoff != "" {
+			if t, err := time.Parse(time.RFC3339, cutoff); err == nil {
+				out.Capabilities.KnowledgeCutoff = t
+			}
+		}
+	}
+
+	if len(m.GetOfferings()) > 0 {
+		out.Offerings = make([]catwalk.ModelOffering, 0, len(m.GetOfferings()))
+		for _, o := range m.GetOfferings() {
+			out.Offerings = append(out.Offerings, catwalk.ModelOffering{
+				InferenceProvider:  catwalk.InferenceProvider(o.GetInferenceProvider()),
+				ModelID:            o.GetModelId(),
+				APIEndpoint:        o.GetApiEndpoint(),
+				ContextWindow:      o.GetContextWindow(),
+				CostPer1MIn:        o.GetCostPer1MIn(),
+				CostPer1MOut:       o.GetCostPer1MOut(),
+				CostPer1MInCached:  o.GetCostPer1MInCached(),
+				CostPer1MOutCached: o.GetCostPer1MOutCached(),
+			})
+		}
+	}
+
+	return out
+}
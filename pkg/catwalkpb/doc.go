@@ -0,0 +1,15 @@
+// Package catwalkpb is the protobuf/gRPC surface for the provider catalog
+// (see catwalk.proto for the wire schema): a typed, streamable alternative
+// to polling the JSON/HTTP /v2/providers routes in package main.
+//
+// catwalk.pb.go and catwalk_grpc.pb.go are generated from catwalk.proto by
+// `buf generate pkg/catwalkpb` (see buf.gen.yaml) and aren't checked into
+// the repo, the same way no other generated client code is; `make generate`
+// (or `go generate ./...`) runs that for you, and `make build`/`vet`/`test`
+// and CI all depend on the generate target so nothing tries to build this
+// package against a stale or missing pair of .pb.go files. Server is the
+// hand-written part: it adapts a *providers.Registry to the generated
+// ProviderCatalogServiceServer interface.
+package catwalkpb
+
+//go:generate buf generate
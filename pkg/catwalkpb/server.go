@@ -0,0 +1,148 @@
+package catwalkpb
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Registry is the subset of *providers.Registry Server needs. It's kept as
+// an interface, rather than importing internal/providers directly, so this
+// package stays usable from outside the module boundary it's itself part
+// of.
+type Registry interface {
+	// Providers returns the currently published provider list.
+	Providers() []catwalk.Provider
+	// Subscribe returns a channel that receives every snapshot published
+	// after the call, until ctx is done.
+	Subscribe(ctx context.Context) <-chan []catwalk.Provider
+}
+
+// ExternalRegistry is implemented by registries that also support
+// RegisterProvider: adding a provider dynamically for as long as some
+// caller-controlled scope (here, a gRPC connection) keeps it registered.
+// *providers.Registry implements this; a Registry passed to NewServer that
+// doesn't makes RegisterProvider reply Unimplemented.
+type ExternalRegistry interface {
+	Registry
+
+	// RegisterExternal adds provider to the catalog until the returned
+	// unregister func is called.
+	RegisterExternal(provider catwalk.Provider) (unregister func())
+}
+
+// Server implements ProviderCatalogServiceServer (see catwalk_grpc.pb.go)
+// backed by a Registry.
+type Server struct {
+	UnimplementedProviderCatalogServiceServer
+
+	registry Registry
+}
+
+// NewServer returns a Server serving registry's catalog.
+func NewServer(registry Registry) *Server {
+	return &Server{registry: registry}
+}
+
+// ListProviders returns the full provider catalog.
+func (s *Server) ListProviders(_ context.Context, _ *ListProvidersRequest) (*ListProvidersResponse, error) {
+	return &ListProvidersResponse{Providers: FromProviders(s.registry.Providers())}, nil
+}
+
+// GetProvider returns a single provider by ID.
+func (s *Server) GetProvider(_ context.Context, req *GetProviderRequest) (*GetProviderResponse, error) {
+	for _, p := range s.registry.Providers() {
+		if string(p.ID) == req.GetId() {
+			return &GetProviderResponse{Provider: FromProvider(p)}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "provider %q not found", req.GetId())
+}
+
+// ListModels returns every model across all providers, or just
+// req.ProviderId's models when set.
+func (s *Server) ListModels(_ context.Context, req *ListModelsRequest) (*ListModelsResponse, error) {
+	var models []*Model
+	for _, p := range s.registry.Providers() {
+		if req.GetProviderId() != "" && string(p.ID) != req.GetProviderId() {
+			continue
+		}
+		for _, m := range p.Models {
+			if req.GetKind() != "" && string(m.EffectiveKind()) != req.GetKind() {
+				continue
+			}
+			models = append(models, FromModel(m))
+		}
+	}
+	return &ListModelsResponse{Models: models}, nil
+}
+
+// WatchProviders streams the full catalog once on connect and again every
+// time the registry publishes a new snapshot, until the client disconnects.
+func (s *Server) WatchProviders(_ *WatchProvidersRequest, stream ProviderCatalogService_WatchProvidersServer) error {
+	ctx := stream.Context()
+	updates := s.registry.Subscribe(ctx)
+
+	if err := stream.Send(&WatchProvidersResponse{Providers: FromProviders(s.registry.Providers())}); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case provs, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(&WatchProvidersResponse{Providers: FromProviders(provs)}); err != nil {
+				return err //nolint:wrapcheck
+			}
+		}
+	}
+}
+
+// RegisterProvider lets a client advertise a custom provider for as long as
+// the stream stays open: each RegisterProviderRequest received replaces the
+// connection's previously registered provider, and the registration is
+// removed as soon as the stream ends (client disconnect, error, or cancel).
+func (s *Server) RegisterProvider(stream ProviderCatalogService_RegisterProviderServer) error {
+	ext, ok := s.registry.(ExternalRegistry)
+	if !ok {
+		return status.Error(codes.Unimplemented, "this server's registry does not support RegisterProvider")
+	}
+
+	var unregister func()
+	defer func() {
+		if unregister != nil {
+			unregister()
+		}
+	}()
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		provider := ToProvider(req.GetProvider())
+		if unregister != nil {
+			unregister()
+		}
+		unregister = ext.RegisterExternal(provider)
+
+		if err := stream.Send(&RegisterProviderResponse{
+			ProviderId: string(provider.ID),
+			ModelCount: int64(len(provider.Models)),
+		}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
@@ -3,11 +3,267 @@
 package embedded
 
 import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+
 	"github.com/charmbracelet/catwalk/internal/providers"
 	"github.com/charmbracelet/catwalk/pkg/catwalk"
 )
 
-// GetAll returns all embedded providers.
+// ProviderFunc builds a catwalk.Provider on demand. Register it under a
+// catwalk.InferenceProvider with Register (or the package-level Register) so
+// callers that need a private/enterprise backend -- a corporate proxy, a
+// self-hosted vLLM deployment, a Groq/Together endpoint -- don't have to
+// fork Catwalk to add one.
+type ProviderFunc func() catwalk.Provider
+
+// DuplicateProviderError is returned by Register when id is already
+// registered and the call didn't pass WithOverwrite.
+type DuplicateProviderError struct {
+	ID catwalk.InferenceProvider
+}
+
+// Error implements error.
+func (e *DuplicateProviderError) Error() string {
+	return fmt.Sprintf("embedded: provider %q already registered", e.ID)
+}
+
+// Registry is an isolated collection of provider funcs. The package-level
+// GetAll, GetByID, GetAvailableIDs and Register all operate on a shared
+// default Registry; build one directly with New when a caller -- a test, or
+// a per-tenant deployment -- needs a hermetic registry instead of mutating
+// that global state.
+type Registry struct {
+	mu         sync.RWMutex
+	providers  map[catwalk.InferenceProvider]ProviderFunc
+	httpClient *http.Client
+	filter     func(catwalk.Model) bool
+	overrides  map[catwalk.InferenceProvider]catwalk.Provider
+}
+
+// Option configures a Registry constructed by New.
+type Option func(*Registry)
+
+// WithProvider registers fn under id as part of constructing the Registry,
+// equivalent to calling Register(id, fn) immediately after New returns.
+func WithProvider(id catwalk.InferenceProvider, fn ProviderFunc) Option {
+	return func(r *Registry) { r.providers[id] = fn }
+}
+
+// WithHTTPClient sets the *http.Client exposed via Registry.HTTPClient, for
+// ProviderFunc implementations that need to self-probe an endpoint (e.g. to
+// confirm a self-hosted vLLM deployment is reachable) before returning their
+// catwalk.Provider.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(r *Registry) { r.httpClient = hc }
+}
+
+// WithModelFilter restricts every provider's Models to those for which
+// keep returns true. Nil (the default) keeps every model.
+func WithModelFilter(keep func(catwalk.Model) bool) Option {
+	return func(r *Registry) { r.filter = keep }
+}
+
+// WithOverrides merges fields from overrides onto the matching provider
+// after it's built, replacing it outright: pass the full catwalk.Provider
+// a caller wants served for that ID rather than a partial patch.
+func WithOverrides(overrides map[catwalk.InferenceProvider]catwalk.Provider) Option {
+	return func(r *Registry) {
+		if r.overrides == nil {
+			r.overrides = make(map[catwalk.InferenceProvider]catwalk.Provider, len(overrides))
+		}
+		for id, p := range overrides {
+			r.overrides[id] = p
+		}
+	}
+}
+
+// RegisterOption configures a single Register call.
+type RegisterOption func(*registerConfig)
+
+type registerConfig struct {
+	overwrite bool
+}
+
+// WithOverwrite lets Register replace an already-registered provider instead
+// of returning a *DuplicateProviderError.
+func WithOverwrite() RegisterOption {
+	return func(c *registerConfig) { c.overwrite = true }
+}
+
+// New builds a Registry seeded from the embedded static provider configs
+// (the same ones the package-level GetAll reads), with opts applied on top.
+func New(opts ...Option) *Registry {
+	r := &Registry{
+		providers: make(map[catwalk.InferenceProvider]ProviderFunc),
+	}
+	for _, p := range providers.GetAll() {
+		p := p
+		r.providers[p.ID] = func() catwalk.Provider { return p }
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Register adds fn under id, returning a *DuplicateProviderError if id is
+// already registered and opts doesn't include WithOverwrite.
+func (r *Registry) Register(id catwalk.InferenceProvider, fn ProviderFunc, opts ...RegisterOption) error {
+	var cfg registerConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.providers[id]; exists && !cfg.overwrite {
+		return &DuplicateProviderError{ID: id}
+	}
+	r.providers[id] = fn
+	return nil
+}
+
+// HTTPClient returns the *http.Client set with WithHTTPClient, or nil if
+// none was set.
+func (r *Registry) HTTPClient() *http.Client {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.httpClient
+}
+
+// build constructs the provider registered under id, applying WithOverrides
+// and WithModelFilter. Callers must hold at least r.mu.RLock.
+func (r *Registry) build(id catwalk.InferenceProvider, fn ProviderFunc) catwalk.Provider {
+	p := fn()
+	if override, ok := r.overrides[id]; ok {
+		p = override
+	}
+	if r.filter != nil {
+		p.Models = slices.DeleteFunc(slices.Clone(p.Models), func(m catwalk.Model) bool {
+			return !r.filter(m)
+		})
+	}
+	return p
+}
+
+// GetAll returns every registered provider, sorted by ID, with WithOverrides
+// and WithModelFilter applied.
+func (r *Registry) GetAll() []catwalk.Provider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]catwalk.Provider, 0, len(r.providers))
+	for id, fn := range r.providers {
+		all = append(all, r.build(id, fn))
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all
+}
+
+// GetByID returns the provider registered under id, building it fresh with
+// WithOverrides and WithModelFilter applied.
+func (r *Registry) GetByID(id catwalk.InferenceProvider) (catwalk.Provider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, exists := r.providers[id]
+	if !exists {
+		return catwalk.Provider{}, false
+	}
+	return r.build(id, fn), true
+}
+
+// GetAvailableIDs returns the IDs of every registered provider.
+func (r *Registry) GetAvailableIDs() []catwalk.InferenceProvider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]catwalk.InferenceProvider, 0, len(r.providers))
+	for id := range r.providers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// GetByCapability returns every registered provider with its Models
+// filtered down to those whose Supports(cap) is true for every cap in
+// caps. A provider left with no matching models is omitted entirely, so a
+// caller like "cheapest model in any provider that supports tool-use +
+// vision + a >=200k context window" can range over the result without
+// re-checking capabilities itself. caps with no entries returns every
+// provider unfiltered, same as GetAll.
+func (r *Registry) GetByCapability(caps ...catwalk.Capability) []catwalk.Provider {
+	all := r.GetAll()
+	if len(caps) == 0 {
+		return all
+	}
+
+	out := make([]catwalk.Provider, 0, len(all))
+	for _, p := range all {
+		models := slices.DeleteFunc(slices.Clone(p.Models), func(m catwalk.Model) bool {
+			for _, cap := range caps {
+				if !m.Supports(cap) {
+					return true
+				}
+			}
+			return false
+		})
+		if len(models) == 0 {
+			continue
+		}
+		p.Models = models
+		out = append(out, p)
+	}
+	return out
+}
+
+var (
+	defaultMu  sync.Mutex
+	defaultReg *Registry
+)
+
+func defaultRegistry() *Registry {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultReg == nil {
+		defaultReg = New()
+	}
+	return defaultReg
+}
+
+// GetAll returns all embedded providers, including any registered with
+// Register.
 func GetAll() []catwalk.Provider {
-	return providers.GetAll()
+	return defaultRegistry().GetAll()
+}
+
+// GetByID returns the embedded provider with the given ID, including any
+// registered with Register.
+func GetByID(id catwalk.InferenceProvider) (catwalk.Provider, bool) {
+	return defaultRegistry().GetByID(id)
+}
+
+// GetAvailableIDs returns the IDs of every embedded provider, including any
+// registered with Register.
+func GetAvailableIDs() []catwalk.InferenceProvider {
+	return defaultRegistry().GetAvailableIDs()
+}
+
+// GetByCapability returns every embedded provider, including any registered
+// with Register, with its Models filtered down to those supporting every
+// capability in caps.
+func GetByCapability(caps ...catwalk.Capability) []catwalk.Provider {
+	return defaultRegistry().GetByCapability(caps...)
+}
+
+// Register adds fn to the package-level default registry used by GetAll,
+// GetByID and GetAvailableIDs, returning a *DuplicateProviderError if id is
+// already registered and opts doesn't include WithOverwrite.
+func Register(id catwalk.InferenceProvider, fn ProviderFunc, opts ...RegisterOption) error {
+	return defaultRegistry().Register(id, fn, opts...)
 }
@@ -0,0 +1,91 @@
+package embedded
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+func TestRegistryRegisterDuplicate(t *testing.T) {
+	r := New()
+
+	fn := func() catwalk.Provider { return catwalk.Provider{ID: "acme"} }
+	if err := r.Register("acme", fn); err != nil {
+		t.Fatalf("Register() first call: %v", err)
+	}
+
+	err := r.Register("acme", fn)
+	var dupErr *DuplicateProviderError
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("Register() second call = %v, want *DuplicateProviderError", err)
+	}
+
+	if err := r.Register("acme", fn, WithOverwrite()); err != nil {
+		t.Fatalf("Register() with WithOverwrite: %v", err)
+	}
+}
+
+func TestRegistryIsolatedFromGlobal(t *testing.T) {
+	r := New(WithProvider("acme", func() catwalk.Provider { return catwalk.Provider{ID: "acme"} }))
+
+	if _, ok := r.GetByID("acme"); !ok {
+		t.Fatal("GetByID(\"acme\") = false, want true on the isolated registry")
+	}
+	if _, ok := GetByID("acme"); ok {
+		t.Fatal("GetByID(\"acme\") = true on the package-level registry, want false")
+	}
+}
+
+func TestRegistryWithModelFilter(t *testing.T) {
+	r := New(WithProvider("acme", func() catwalk.Provider {
+		return catwalk.Provider{
+			ID: "acme",
+			Models: []catwalk.Model{
+				{ID: "small", ContextWindow: 8_000},
+				{ID: "large", ContextWindow: 128_000},
+			},
+		}
+	}), WithModelFilter(func(m catwalk.Model) bool {
+		return m.ContextWindow >= 100_000
+	}))
+
+	p, ok := r.GetByID("acme")
+	if !ok {
+		t.Fatal("GetByID(\"acme\") = false, want true")
+	}
+	if len(p.Models) != 1 || p.Models[0].ID != "large" {
+		t.Fatalf("Models = %v, want only %q", p.Models, "large")
+	}
+}
+
+func TestRegistryWithOverrides(t *testing.T) {
+	r := New(
+		WithProvider("acme", func() catwalk.Provider { return catwalk.Provider{ID: "acme", Name: "Acme"} }),
+		WithOverrides(map[catwalk.InferenceProvider]catwalk.Provider{
+			"acme": {ID: "acme", Name: "Acme (custom)"},
+		}),
+	)
+
+	p, ok := r.GetByID("acme")
+	if !ok {
+		t.Fatal("GetByID(\"acme\") = false, want true")
+	}
+	if p.Name != "Acme (custom)" {
+		t.Fatalf("Name = %q, want %q", p.Name, "Acme (custom)")
+	}
+}
+
+func TestGetAllSortedByID(t *testing.T) {
+	r := New(
+		WithProvider("zzz", func() catwalk.Provider { return catwalk.Provider{ID: "zzz"} }),
+		WithProvider("aaa", func() catwalk.Provider { return catwalk.Provider{ID: "aaa"} }),
+	)
+
+	all := r.GetAll()
+	for i := 1; i < len(all); i++ {
+		if all[i-1].ID > all[i].ID {
+			t.Fatalf("GetAll() not sorted by ID: %v", all)
+		}
+	}
+}
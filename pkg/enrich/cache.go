@@ -0,0 +1,483 @@
+package enrich
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// hashString returns the content address used to key
+// reasoning_effort_cache, so a changed description invalidates the cached
+// classification.
+func hashString(s string) string {
+	hash := sha256.Sum256([]byte(s))
+	return fmt.Sprintf("%x", hash)
+}
+
+// cacheHashAlgo identifies the hashing scheme hashString uses, recorded
+// alongside every display_name_cache and reasoning_effort_cache row in its
+// hash_algo column. Get and friends filter on the current value so a future
+// switch to a different algorithm treats rows hashed the old way as misses
+// instead of matching a description_hash that means something different now.
+const cacheHashAlgo = "sha256"
+
+// defaultCacheTTL is how long a cached display name is trusted before a Get
+// treats it as a miss, even if the model metadata hasn't changed.
+const defaultCacheTTL = 30 * 24 * time.Hour
+
+// defaultMaxEntries bounds how many rows display_name_cache is allowed to
+// grow to. Once exceeded, the least-recently-used rows are evicted.
+const defaultMaxEntries = 10000
+
+// sweepInterval is how often the background sweep goroutine purges entries
+// older than the cache's TTL, so a long-lived generator process doesn't
+// accumulate stale rows between runs instead of only ever cleaning up on
+// the next Get/Set.
+const sweepInterval = time.Hour
+
+// CacheOptions configures a Cache beyond NewCache's defaults. The zero
+// value of each field falls back to the package default.
+type CacheOptions struct {
+	// MaxAge overrides defaultCacheTTL.
+	MaxAge time.Duration
+	// MaxRows overrides defaultMaxEntries.
+	MaxRows int
+	// VacuumOnClose runs VACUUM when Close is called, reclaiming the disk
+	// space freed by eviction and the background sweep. Off by default
+	// since VACUUM rewrites the whole database file and can be slow for a
+	// large cache.
+	VacuumOnClose bool
+}
+
+// Cache manages the SQLite database an LLM-backed Enricher uses to avoid
+// re-querying its LLM for a model whose metadata hasn't changed. It was
+// originally cmd/apipie's unexported Cache, later duplicated into
+// cmd/huggingface; this is the merged, generator-agnostic version both (and
+// future generators) share.
+type Cache struct {
+	db            *sql.DB
+	ttl           time.Duration
+	maxEntries    int
+	vacuumOnClose bool
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewCache creates a new cache instance, using the package defaults for TTL
+// and max row count, and initializes the database.
+func NewCache(dbPath string) (*Cache, error) {
+	return NewCacheWithOptions(dbPath, CacheOptions{})
+}
+
+// NewCacheWithOptions is NewCache with explicit CacheOptions.
+func NewCacheWithOptions(dbPath string, opts CacheOptions) (*Cache, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	ttl := opts.MaxAge
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	maxEntries := opts.MaxRows
+	if maxEntries <= 0 {
+		maxEntries = defaultMaxEntries
+	}
+
+	cache := &Cache{
+		db:            db,
+		ttl:           ttl,
+		maxEntries:    maxEntries,
+		vacuumOnClose: opts.VacuumOnClose,
+		stop:          make(chan struct{}),
+	}
+	if err := runMigrations(context.Background(), db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	cache.wg.Add(1)
+	go cache.sweepLoop()
+
+	return cache, nil
+}
+
+// sweepLoop periodically purges entries older than c.ttl until Close stops
+// it. It runs for the lifetime of the Cache rather than only at Get/Set
+// time, so a process that enriches a handful of models a day still gets
+// the table cleaned up instead of it growing unbounded between runs.
+func (c *Cache) sweepLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.CleanOldEntries(c.ttl); err != nil {
+				log.Printf("enrich: background cache sweep error: %v", err)
+			}
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Close stops the background sweep, optionally VACUUMs, and closes the
+// database connection.
+func (c *Cache) Close() error {
+	close(c.stop)
+	c.wg.Wait()
+
+	if c.vacuumOnClose {
+		if _, err := c.db.Exec("VACUUM"); err != nil {
+			log.Printf("enrich: cache vacuum error: %v", err)
+		}
+	}
+
+	return c.db.Close()
+}
+
+// keyColumn is the fixed description_hash value used for display_name_cache
+// rows keyed by key instead of cmd/apipie's original (model_id,
+// description_hash) pair: RawModel.CacheKey already folds the model's
+// metadata into the key itself, so the second column isn't needed to
+// disambiguate.
+const keyColumn = ""
+
+// Get retrieves a cached display name for key (a RawModel.CacheKey).
+// Returns empty string if not found or the entry is older than the cache
+// TTL.
+func (c *Cache) Get(key string) string {
+	var displayName string
+	var createdAt time.Time
+	query := `SELECT display_name, created_at FROM display_name_cache
+			  WHERE model_id = ? AND description_hash = ? AND hash_algo = ?`
+
+	err := c.db.QueryRow(query, key, keyColumn, cacheHashAlgo).Scan(&displayName, &createdAt)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("enrich: cache get error for %s: %v", key, err)
+		}
+		return ""
+	}
+
+	if time.Since(createdAt) > c.ttl {
+		return ""
+	}
+
+	c.touch(key)
+	return displayName
+}
+
+// GetMany is Get for a batch of keys, in a single transaction instead of
+// one round trip per key: DisplayNames calls it once per group rather than
+// looping Get over every group member. Keys not found, or found but past
+// the cache TTL, are simply absent from the returned map.
+func (c *Cache) GetMany(keys []string) (map[string]string, error) {
+	out := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return out, nil
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin cache batch get: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	placeholders := make([]string, len(keys))
+	selectArgs := make([]any, 0, len(keys)+2)
+	selectArgs = append(selectArgs, keyColumn, cacheHashAlgo)
+	for i, key := range keys {
+		placeholders[i] = "?"
+		selectArgs = append(selectArgs, key)
+	}
+	query := fmt.Sprintf(
+		`SELECT model_id, display_name, created_at FROM display_name_cache
+		 WHERE description_hash = ? AND hash_algo = ? AND model_id IN (%s)`,
+		strings.Join(placeholders, ","),
+	)
+
+	rows, err := tx.Query(query, selectArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get display names: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []string
+	for rows.Next() {
+		var key, displayName string
+		var createdAt time.Time
+		if err := rows.Scan(&key, &displayName, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan batch get row: %w", err)
+		}
+		if time.Since(createdAt) > c.ttl {
+			continue
+		}
+		out[key] = displayName
+		hits = append(hits, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch get rows: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close batch get rows: %w", err)
+	}
+
+	if len(hits) > 0 {
+		touchPlaceholders := make([]string, len(hits))
+		touchArgs := make([]any, 0, len(hits)+3)
+		touchArgs = append(touchArgs, time.Now(), keyColumn, cacheHashAlgo)
+		for i, key := range hits {
+			touchPlaceholders[i] = "?"
+			touchArgs = append(touchArgs, key)
+		}
+		touchQuery := fmt.Sprintf(
+			`UPDATE display_name_cache SET last_accessed_at = ?
+			 WHERE description_hash = ? AND hash_algo = ? AND model_id IN (%s)`,
+			strings.Join(touchPlaceholders, ","),
+		)
+		if _, err := tx.Exec(touchQuery, touchArgs...); err != nil {
+			return nil, fmt.Errorf("failed to touch batch get hits: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit batch get: %w", err)
+	}
+
+	return out, nil
+}
+
+// touch updates the last_accessed_at timestamp for an entry so LRU eviction
+// can tell it apart from entries nobody has asked for in a while.
+func (c *Cache) touch(key string) {
+	_, err := c.db.Exec(
+		`UPDATE display_name_cache SET last_accessed_at = ? WHERE model_id = ? AND description_hash = ? AND hash_algo = ?`,
+		time.Now(), key, keyColumn, cacheHashAlgo,
+	)
+	if err != nil {
+		log.Printf("enrich: cache touch error for %s: %v", key, err)
+	}
+}
+
+// Set stores a display name in the cache under key and evicts
+// least-recently-used entries if the cache has grown past maxEntries.
+func (c *Cache) Set(key, displayName string) error {
+	now := time.Now()
+	query := `INSERT OR REPLACE INTO display_name_cache
+			  (model_id, description_hash, display_name, created_at, last_accessed_at, hash_algo)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+
+	if _, err := c.db.Exec(query, key, keyColumn, displayName, now, now, cacheHashAlgo); err != nil {
+		return fmt.Errorf("failed to cache display name for %s: %w", key, err)
+	}
+
+	if err := c.evictLRU(); err != nil {
+		log.Printf("enrich: cache eviction error: %v", err)
+	}
+
+	return nil
+}
+
+// CacheEntry is one row to write with SetMany: a RawModel.CacheKey paired
+// with the display name resolved for it.
+type CacheEntry struct {
+	Key         string
+	DisplayName string
+}
+
+// SetMany is Set for a batch of entries, in a single transaction instead of
+// one round trip per entry: DisplayNames calls it once per group rather
+// than looping Set over every resolved member.
+func (c *Cache) SetMany(entries []CacheEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin cache batch set: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO display_name_cache
+		(model_id, description_hash, display_name, created_at, last_accessed_at, hash_algo)
+		VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare cache batch set: %w", err)
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, entry := range entries {
+		if _, err := stmt.Exec(entry.Key, keyColumn, entry.DisplayName, now, now, cacheHashAlgo); err != nil {
+			return fmt.Errorf("failed to cache display name for %s: %w", entry.Key, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit cache batch set: %w", err)
+	}
+
+	if err := c.evictLRU(); err != nil {
+		log.Printf("enrich: cache eviction error: %v", err)
+	}
+
+	return nil
+}
+
+// evictLRU removes the least-recently-used display_name_cache rows once the
+// table has grown past maxEntries.
+func (c *Cache) evictLRU() error {
+	if c.maxEntries <= 0 {
+		return nil
+	}
+
+	query := `
+	DELETE FROM display_name_cache
+	WHERE rowid IN (
+		SELECT rowid FROM display_name_cache
+		ORDER BY last_accessed_at ASC
+		LIMIT MAX(0, (SELECT COUNT(*) FROM display_name_cache) - ?)
+	)`
+
+	result, err := c.db.Exec(query, c.maxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to evict LRU entries: %w", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+		log.Printf("enrich: evicted %d LRU display name cache entries", rowsAffected)
+	}
+	return nil
+}
+
+// GetStats returns the number of cached display names.
+func (c *Cache) GetStats() (int, error) {
+	var count int
+	err := c.db.QueryRow("SELECT COUNT(*) FROM display_name_cache").Scan(&count)
+	return count, err
+}
+
+// CleanOldEntries removes cache entries older than maxAge.
+func (c *Cache) CleanOldEntries(maxAge time.Duration) error {
+	cutoff := time.Now().Add(-maxAge)
+
+	query := `DELETE FROM display_name_cache WHERE created_at < ?`
+	result, err := c.db.Exec(query, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to clean old display name entries: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+		log.Printf("enrich: cleaned %d old display name cache entries", rowsAffected)
+	}
+
+	query = `DELETE FROM reasoning_effort_cache WHERE created_at < ?`
+	result, err = c.db.Exec(query, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to clean old reasoning effort entries: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+		log.Printf("enrich: cleaned %d old reasoning effort cache entries", rowsAffected)
+	}
+
+	return nil
+}
+
+// GetReasoningEffort retrieves a cached reasoning-effort classification for
+// description.
+func (c *Cache) GetReasoningEffort(description string) (bool, bool) {
+	if description == "" {
+		return false, false
+	}
+
+	hash := hashString(description)
+
+	var hasEffort bool
+	err := c.db.QueryRow(
+		"SELECT has_reasoning_effort FROM reasoning_effort_cache WHERE description_hash = ? AND hash_algo = ?",
+		hash, cacheHashAlgo,
+	).Scan(&hasEffort)
+	if err != nil {
+		return false, false
+	}
+
+	c.touchReasoningEffort(hash)
+	return hasEffort, true
+}
+
+// touchReasoningEffort updates the last_accessed_at timestamp for a
+// reasoning_effort_cache row so it shares display_name_cache's LRU eviction
+// policy instead of only ever being pruned by CleanOldEntries' age cutoff.
+func (c *Cache) touchReasoningEffort(hash string) {
+	_, err := c.db.Exec(
+		`UPDATE reasoning_effort_cache SET last_accessed_at = ? WHERE description_hash = ? AND hash_algo = ?`,
+		time.Now(), hash, cacheHashAlgo,
+	)
+	if err != nil {
+		log.Printf("enrich: reasoning effort cache touch error: %v", err)
+	}
+}
+
+// SetReasoningEffort stores a reasoning-effort classification for
+// description.
+func (c *Cache) SetReasoningEffort(description string, hasEffort bool) error {
+	if description == "" {
+		return nil
+	}
+
+	hash := hashString(description)
+	now := time.Now()
+	_, err := c.db.Exec(
+		"INSERT OR REPLACE INTO reasoning_effort_cache (description_hash, has_reasoning_effort, created_at, last_accessed_at, hash_algo) VALUES (?, ?, ?, ?, ?)",
+		hash, hasEffort, now, now, cacheHashAlgo,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cache reasoning effort: %w", err)
+	}
+
+	if err := c.evictReasoningLRU(); err != nil {
+		log.Printf("enrich: reasoning effort cache eviction error: %v", err)
+	}
+
+	return nil
+}
+
+// evictReasoningLRU removes the least-recently-used reasoning_effort_cache
+// rows once the table has grown past maxEntries, the same policy evictLRU
+// applies to display_name_cache.
+func (c *Cache) evictReasoningLRU() error {
+	if c.maxEntries <= 0 {
+		return nil
+	}
+
+	query := `
+	DELETE FROM reasoning_effort_cache
+	WHERE rowid IN (
+		SELECT rowid FROM reasoning_effort_cache
+		ORDER BY last_accessed_at ASC
+		LIMIT MAX(0, (SELECT COUNT(*) FROM reasoning_effort_cache) - ?)
+	)`
+
+	result, err := c.db.Exec(query, c.maxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to evict LRU reasoning effort entries: %w", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+		log.Printf("enrich: evicted %d LRU reasoning effort cache entries", rowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package enrich
+
+import "context"
+
+// Chain runs Heuristic first and only falls back to an LLM-backed Enricher
+// for the cases Heuristic can't confidently resolve on its own: a model it
+// flagged as reasoning-capable (where whether the reasoning effort is
+// controllable needs the LLM's read of the free-form description), and
+// naming, which Heuristic can only do by falling back to the raw model ID.
+type Chain struct {
+	Heuristic Enricher
+	Fallback  Enricher
+}
+
+// NewChain builds a Chain that tries NewHeuristic() first and calls
+// fallback for whatever it leaves ambiguous.
+func NewChain(fallback Enricher) *Chain {
+	return &Chain{Heuristic: NewHeuristic(), Fallback: fallback}
+}
+
+// Classify implements Enricher: Heuristic's result is used as-is, except
+// HasReasoningEffort is replaced by the fallback's when Heuristic found the
+// model reasoning-capable but the description alone doesn't say whether the
+// effort is controllable.
+func (c *Chain) Classify(ctx context.Context, model RawModel) Capabilities {
+	caps := c.Heuristic.Classify(ctx, model)
+	if !caps.CanReason || caps.HasReasoningEffort {
+		return caps
+	}
+
+	fallback := c.Fallback.Classify(ctx, model)
+	caps.HasReasoningEffort = fallback.HasReasoningEffort
+	return caps
+}
+
+// DisplayNames implements Enricher: names come from Fallback, with
+// Heuristic's ID-based names filling in anything Fallback left unresolved
+// (e.g. it's offline, or the LLM call failed for that group).
+func (c *Chain) DisplayNames(ctx context.Context, group []RawModel) map[string]string {
+	names := c.Fallback.DisplayNames(ctx, group)
+	if names == nil {
+		return c.Heuristic.DisplayNames(ctx, group)
+	}
+
+	fallbackNames := c.Heuristic.DisplayNames(ctx, group)
+	for key, name := range fallbackNames {
+		if _, ok := names[key]; !ok {
+			names[key] = name
+		}
+	}
+	return names
+}
@@ -0,0 +1,60 @@
+package enrich
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeEnricher is a minimal Enricher stub for testing Chain's fallback
+// wiring without depending on an LLM-backed implementation.
+type fakeEnricher struct {
+	names map[string]string
+	caps  Capabilities
+}
+
+func (f *fakeEnricher) DisplayNames(_ context.Context, group []RawModel) map[string]string {
+	if f.names == nil {
+		return nil
+	}
+	result := make(map[string]string, len(group))
+	for _, m := range group {
+		if name, ok := f.names[m.CacheKey()]; ok {
+			result[m.CacheKey()] = name
+		}
+	}
+	return result
+}
+
+func (f *fakeEnricher) Classify(_ context.Context, _ RawModel) Capabilities {
+	return f.caps
+}
+
+func TestChainClassifyUsesFallbackOnlyForReasoningEffort(t *testing.T) {
+	fallback := &fakeEnricher{caps: Capabilities{HasReasoningEffort: true}}
+	chain := NewChain(fallback)
+
+	caps := chain.Classify(context.Background(), RawModel{Subtype: "reasoning"})
+	if !caps.CanReason || !caps.HasReasoningEffort {
+		t.Errorf("expected fallback to resolve HasReasoningEffort, got %+v", caps)
+	}
+
+	caps = chain.Classify(context.Background(), RawModel{})
+	if caps.CanReason || caps.HasReasoningEffort {
+		t.Errorf("expected non-reasoning model to skip fallback, got %+v", caps)
+	}
+}
+
+func TestChainDisplayNamesFillsGapsFromHeuristic(t *testing.T) {
+	m1 := RawModel{ID: "m1"}
+	m2 := RawModel{ID: "m2"}
+	fallback := &fakeEnricher{names: map[string]string{m1.CacheKey(): "Model One"}}
+	chain := NewChain(fallback)
+
+	names := chain.DisplayNames(context.Background(), []RawModel{m1, m2})
+	if names[m1.CacheKey()] != "Model One" {
+		t.Errorf("expected fallback name for m1, got %s", names[m1.CacheKey()])
+	}
+	if names[m2.CacheKey()] != m2.ID {
+		t.Errorf("expected heuristic ID fallback for m2, got %s", names[m2.CacheKey()])
+	}
+}
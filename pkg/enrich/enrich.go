@@ -0,0 +1,85 @@
+// Package enrich provides reusable LLM-assisted (and LLM-free) model
+// classification for provider generators: a friendly display name for a
+// model, and the capability flags (reasoning, modalities, embedding/TTS/
+// transcription) a generator can't reliably read off an upstream API as a
+// single boolean.
+//
+// It was extracted out of cmd/apipie and cmd/huggingface, which originally
+// each had their own copy of this logic (display-name generation, reasoning
+// classification, and the SQLite cache backing both). Generators that don't
+// have (or don't want to spend) an LLM API key still get useful results:
+// Heuristic needs no network access at all, and Chain only calls out to an
+// LLM-backed Enricher for the cases Heuristic can't confidently resolve on
+// its own.
+package enrich
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+// RawModel is the generator-agnostic view of a model that an Enricher needs
+// in order to name it or classify its capabilities. Generators populate
+// whatever fields their upstream API exposes; zero-valued fields are simply
+// not used as signal.
+type RawModel struct {
+	ID               string
+	BaseModel        string
+	Provider         string
+	Subtype          string
+	Tags             []string
+	InputModalities  []string
+	OutputModalities []string
+	ContextWindow    int64
+	Description      string
+}
+
+// CacheKey returns a key that's unique per (ID, metadata) pair, not just
+// per ID: several generators (apipie in particular) see the same model ID
+// served by more than one provider/route with different metadata, and
+// those need separate cached display names/classifications rather than
+// colliding on ID alone. DisplayNames results are keyed by this, not by
+// RawModel.ID.
+func (m RawModel) CacheKey() string {
+	return m.ID + "|" + m.metadataHash()
+}
+
+func (m RawModel) metadataHash() string {
+	metadata := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%d",
+		m.Description,
+		m.Provider,
+		m.Subtype,
+		m.BaseModel,
+		strings.Join(m.InputModalities, ","),
+		strings.Join(m.OutputModalities, ","),
+		m.ContextWindow,
+	)
+	hash := sha256.Sum256([]byte(metadata))
+	return fmt.Sprintf("%x", hash)
+}
+
+// Capabilities holds the classification flags an Enricher derives for a
+// single RawModel.
+type Capabilities struct {
+	CanReason          bool
+	HasReasoningEffort bool
+	SupportsImages     bool
+	SupportsAudio      bool
+	SupportsVideo      bool
+	IsEmbedding        bool
+	IsTTS              bool
+	IsTranscription    bool
+}
+
+// Enricher generates display names for a group of models sharing an ID, and
+// classifies a single model's capabilities.
+type Enricher interface {
+	// DisplayNames generates display names for a group of models that share
+	// a model ID, helping users differentiate between variants. It returns
+	// a map keyed by RawModel.CacheKey, not RawModel.ID.
+	DisplayNames(ctx context.Context, group []RawModel) map[string]string
+	// Classify derives model's Capabilities.
+	Classify(ctx context.Context, model RawModel) Capabilities
+}
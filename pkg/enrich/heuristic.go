@@ -0,0 +1,88 @@
+package enrich
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// controllableReasoningIndicators are phrases in a model's description that
+// suggest it supports a configurable reasoning depth.
+var controllableReasoningIndicators = []string{
+	"thinking tokens", "reasoning budget", "controllable reasoning",
+	"thinking depth", "reasoning depth", "controllable depth",
+	"thinking budget", "reasoning effort", "configurable reasoning",
+	"adjustable reasoning",
+}
+
+// Heuristic classifies and names models using only static rules over
+// RawModel's fields: subtype, tags, modalities and description. It never
+// makes a network call, so it's always available as a baseline or as
+// Chain's fast path, and as the sole Enricher for generators/tests that
+// shouldn't depend on an LLM at all.
+type Heuristic struct{}
+
+// NewHeuristic returns a Heuristic Enricher.
+func NewHeuristic() *Heuristic {
+	return &Heuristic{}
+}
+
+// Classify implements Enricher.
+func (Heuristic) Classify(_ context.Context, m RawModel) Capabilities {
+	reason := canReasonHeuristic(m)
+	return Capabilities{
+		CanReason:          reason,
+		HasReasoningEffort: reason && hasControllableReasoningIndicator(m.Description),
+		SupportsImages:     hasModality(m, "image") || matchesAny(m.Subtype, "multimodal", "vision") || matchesAny(m.Description, "vision", "image"),
+		SupportsAudio:      hasModality(m, "audio") || matchesAny(m.Subtype, "audio") || slices.Contains(m.Tags, "audio"),
+		SupportsVideo:      hasModality(m, "video") || matchesAny(m.Subtype, "video") || slices.Contains(m.Tags, "video"),
+		IsEmbedding:        matchesAny(m.Subtype, "embedding") || slices.Contains(m.Tags, "embedding") || slices.Contains(m.Tags, "feature-extraction"),
+		IsTTS:              matchesAny(m.Subtype, "tts", "text-to-speech") || slices.Contains(m.Tags, "text-to-speech"),
+		IsTranscription:    matchesAny(m.Subtype, "transcription", "speech-to-text", "whisper") || slices.Contains(m.Tags, "automatic-speech-recognition"),
+	}
+}
+
+// DisplayNames implements Enricher, falling back to the bare model ID for
+// every entry since Heuristic has no naming model to consult.
+func (Heuristic) DisplayNames(_ context.Context, group []RawModel) map[string]string {
+	names := make(map[string]string, len(group))
+	for _, m := range group {
+		names[m.CacheKey()] = m.ID
+	}
+	return names
+}
+
+func canReasonHeuristic(m RawModel) bool {
+	return matchesAny(m.Subtype, "reasoning") || slices.Contains(m.Tags, "reasoning")
+}
+
+func hasControllableReasoningIndicator(description string) bool {
+	if description == "" {
+		return false
+	}
+	desc := strings.ToLower(description)
+	for _, indicator := range controllableReasoningIndicators {
+		if strings.Contains(desc, indicator) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasModality(m RawModel, modality string) bool {
+	return slices.Contains(m.InputModalities, modality) || slices.Contains(m.OutputModalities, modality)
+}
+
+// matchesAny reports whether s contains any of substrs, case-insensitively.
+func matchesAny(s string, substrs ...string) bool {
+	if s == "" {
+		return false
+	}
+	s = strings.ToLower(s)
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package enrich
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHeuristicClassify(t *testing.T) {
+	h := NewHeuristic()
+
+	caps := h.Classify(context.Background(), RawModel{
+		Subtype:     "reasoning",
+		Description: "Supports a configurable reasoning budget.",
+	})
+	if !caps.CanReason {
+		t.Error("expected reasoning subtype to set CanReason")
+	}
+	if !caps.HasReasoningEffort {
+		t.Error("expected reasoning budget phrase to set HasReasoningEffort")
+	}
+
+	caps = h.Classify(context.Background(), RawModel{Subtype: "reasoning"})
+	if caps.HasReasoningEffort {
+		t.Error("expected no reasoning-effort phrase to leave HasReasoningEffort false")
+	}
+
+	caps = h.Classify(context.Background(), RawModel{Tags: []string{"embedding"}})
+	if !caps.IsEmbedding {
+		t.Error("expected embedding tag to set IsEmbedding")
+	}
+
+	caps = h.Classify(context.Background(), RawModel{Subtype: "vision-multimodal"})
+	if !caps.SupportsImages {
+		t.Error("expected vision subtype to set SupportsImages")
+	}
+}
+
+func TestHeuristicDisplayNamesFallsBackToID(t *testing.T) {
+	h := NewHeuristic()
+	group := []RawModel{{ID: "gpt-5"}, {ID: "gpt-5-mini"}}
+
+	names := h.DisplayNames(context.Background(), group)
+	for _, m := range group {
+		if names[m.CacheKey()] != m.ID {
+			t.Errorf("expected %s, got %s", m.ID, names[m.CacheKey()])
+		}
+	}
+}
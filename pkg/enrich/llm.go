@@ -0,0 +1,114 @@
+package enrich
+
+import (
+	"context"
+	"log"
+
+	"github.com/charmbracelet/catwalk/internal/infer"
+)
+
+// LLM is an Enricher backed by internal/infer.Analyzer (the APIpie-donated
+// chat completion endpoint cmd/apipie originally called directly), with a
+// Cache in front of it so a model whose metadata hasn't changed isn't
+// re-sent to the LLM on every generator run. It delegates everything
+// Analyzer doesn't cover (audio/video/embedding/TTS/transcription
+// detection) to Heuristic.
+type LLM struct {
+	analyzer  *infer.Analyzer
+	cache     *Cache
+	heuristic *Heuristic
+}
+
+// NewLLM builds an LLM Enricher. analyzer runs in offline mode (falling
+// back to static heuristics for ReasoningEffort, and returning nil from
+// DisplayNames) when it has no API key configured -- see
+// infer.NewAnalyzer.
+func NewLLM(analyzer *infer.Analyzer, cache *Cache) *LLM {
+	return &LLM{analyzer: analyzer, cache: cache, heuristic: NewHeuristic()}
+}
+
+// Classify implements Enricher. Only HasReasoningEffort is LLM-derived;
+// every other field comes from Heuristic, since Analyzer doesn't classify
+// modalities or embedding/TTS/transcription.
+func (l *LLM) Classify(ctx context.Context, m RawModel) Capabilities {
+	caps := l.heuristic.Classify(ctx, m)
+	if !caps.CanReason || m.Description == "" {
+		return caps
+	}
+
+	if hasEffort, found := l.cache.GetReasoningEffort(m.Description); found {
+		caps.HasReasoningEffort = hasEffort
+		return caps
+	}
+
+	result := l.analyzer.ReasoningEffort(ctx, m.Description)
+	if err := l.cache.SetReasoningEffort(m.Description, result); err != nil {
+		log.Printf("enrich: failed to cache reasoning effort for %s: %v", m.ID, err)
+	}
+	caps.HasReasoningEffort = result
+	return caps
+}
+
+// DisplayNames implements Enricher: cache-first, then a single Analyzer
+// call for every group member still uncached, falling back to the model ID
+// for any that remain unresolved (Analyzer offline, or the call failed).
+func (l *LLM) DisplayNames(ctx context.Context, group []RawModel) map[string]string {
+	keys := make([]string, len(group))
+	for i, m := range group {
+		keys[i] = m.CacheKey()
+	}
+	cached, err := l.cache.GetMany(keys)
+	if err != nil {
+		log.Printf("enrich: batch cache get failed, falling back to the LLM for the whole group: %v", err)
+		cached = nil
+	}
+
+	result := make(map[string]string, len(group))
+	var uncached []RawModel
+	for _, m := range group {
+		if name, ok := cached[m.CacheKey()]; ok {
+			result[m.CacheKey()] = name
+		} else {
+			uncached = append(uncached, m)
+		}
+	}
+	if len(uncached) == 0 {
+		return result
+	}
+
+	descriptors := make([]infer.ModelDescriptor, len(uncached))
+	for i, m := range uncached {
+		descriptors[i] = infer.ModelDescriptor{
+			ID:               m.CacheKey(),
+			BaseModel:        m.BaseModel,
+			Provider:         m.Provider,
+			Subtype:          m.Subtype,
+			InputModalities:  m.InputModalities,
+			OutputModalities: m.OutputModalities,
+			ContextWindow:    m.ContextWindow,
+			Description:      m.Description,
+		}
+	}
+
+	if names := l.analyzer.DisplayNames(ctx, descriptors); names != nil {
+		var toCache []CacheEntry
+		for _, m := range uncached {
+			name, ok := names[m.CacheKey()]
+			if !ok {
+				continue
+			}
+			result[m.CacheKey()] = name
+			toCache = append(toCache, CacheEntry{Key: m.CacheKey(), DisplayName: name})
+		}
+		if err := l.cache.SetMany(toCache); err != nil {
+			log.Printf("enrich: failed to batch-cache display names: %v", err)
+		}
+	}
+
+	for _, m := range uncached {
+		if _, ok := result[m.CacheKey()]; !ok {
+			result[m.CacheKey()] = m.ID
+		}
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+package enrich
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+)
+
+// migrationHashAlgo identifies the hashing scheme migrationHash uses,
+// recorded alongside each migration's hash in schema_migrations.hash_algo
+// so a future switch to a different algorithm can tell its own hashes
+// apart from ones computed the old way, instead of silently re-running
+// every migration under the new scheme (or mismatching and never
+// re-running the ones that need it).
+const migrationHashAlgo = "sha256"
+
+// migrations are applied in order on every NewCache. Each entry is
+// identified by the SHA256 hash of its own SQL text rather than a sequence
+// number, so migrations can be reordered or have new ones inserted earlier
+// without colliding with hashes already recorded in schema_migrations -
+// what matters is whether this exact statement has run, not its position.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS display_name_cache (
+		model_id TEXT NOT NULL,
+		description_hash TEXT NOT NULL,
+		display_name TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		PRIMARY KEY (model_id, description_hash)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_model_id ON display_name_cache(model_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_created_at ON display_name_cache(created_at)`,
+	`ALTER TABLE display_name_cache ADD COLUMN last_accessed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP`,
+	`CREATE INDEX IF NOT EXISTS idx_last_accessed_at ON display_name_cache(last_accessed_at)`,
+	`CREATE TABLE IF NOT EXISTS reasoning_effort_cache (
+		description_hash TEXT NOT NULL PRIMARY KEY,
+		has_reasoning_effort BOOLEAN NOT NULL,
+		created_at DATETIME NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_reasoning_created_at ON reasoning_effort_cache(created_at)`,
+	`ALTER TABLE display_name_cache ADD COLUMN hash_algo TEXT NOT NULL DEFAULT '` + cacheHashAlgo + `'`,
+	`ALTER TABLE reasoning_effort_cache ADD COLUMN hash_algo TEXT NOT NULL DEFAULT '` + cacheHashAlgo + `'`,
+	`ALTER TABLE reasoning_effort_cache ADD COLUMN last_accessed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP`,
+	`CREATE INDEX IF NOT EXISTS idx_reasoning_last_accessed_at ON reasoning_effort_cache(last_accessed_at)`,
+}
+
+// migrationHash returns the content address for a migration statement,
+// under migrationHashAlgo.
+func migrationHash(sql string) string {
+	hash := sha256.Sum256([]byte(sql))
+	return fmt.Sprintf("%x", hash)
+}
+
+// Migrate applies every migration in migrations whose (hash, hash_algo)
+// pair isn't already recorded in schema_migrations. It's exported, rather
+// than only running from NewCache, so a caller that reset or hand-edited
+// the database file (e.g. the --reset-cache generator flag) can bring the
+// schema back up to date without reopening the Cache.
+func (c *Cache) Migrate(ctx context.Context) error {
+	return runMigrations(ctx, c.db)
+}
+
+// runMigrations applies every migration in migrations whose content hash
+// isn't already recorded in schema_migrations under migrationHashAlgo.
+func runMigrations(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		hash TEXT NOT NULL,
+		hash_algo TEXT NOT NULL DEFAULT '`+migrationHashAlgo+`',
+		applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (hash, hash_algo)
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	for _, stmt := range migrations {
+		hash := migrationHash(stmt)
+
+		var applied int
+		err := db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM schema_migrations WHERE hash = ? AND hash_algo = ?`,
+			hash, migrationHashAlgo,
+		).Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("failed to check migration %s: %w", hash, err)
+		}
+		if applied > 0 {
+			continue
+		}
+
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", hash, err)
+		}
+		if _, err := db.ExecContext(ctx,
+			`INSERT INTO schema_migrations (hash, hash_algo) VALUES (?, ?)`,
+			hash, migrationHashAlgo,
+		); err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", hash, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package enrich
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PoolConfig configures Pool's concurrency and outbound rate limiting.
+type PoolConfig struct {
+	// Concurrency bounds how many Pool tasks run at once. <= 0 means 1.
+	Concurrency int
+	// RatePerSecond bounds how many tasks Pool can start per second, via a
+	// simple token-bucket with a burst of 1. <= 0 disables rate limiting.
+	RatePerSecond float64
+}
+
+// Pool runs LLM-backed enrichment tasks (group naming, reasoning-effort
+// classification) with bounded concurrency and an optional outbound rate
+// limit, so a generator doesn't have to serially re-hit an LLM API once per
+// model group.
+type Pool struct {
+	sem     chan struct{}
+	wg      sync.WaitGroup
+	limiter *rateLimiter
+}
+
+// NewPool builds a Pool from cfg.
+func NewPool(cfg PoolConfig) *Pool {
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	p := &Pool{sem: make(chan struct{}, concurrency)}
+	if cfg.RatePerSecond > 0 {
+		p.limiter = newRateLimiter(cfg.RatePerSecond)
+	}
+	return p
+}
+
+// Go runs fn in its own goroutine once a concurrency slot and (if
+// configured) a rate-limiter token are available. If ctx is cancelled
+// while waiting for either, fn is skipped. Call Wait to block until every
+// Go'd task has returned.
+func (p *Pool) Go(ctx context.Context, fn func()) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		select {
+		case p.sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		defer func() { <-p.sem }()
+
+		if p.limiter != nil {
+			if err := p.limiter.wait(ctx); err != nil {
+				return
+			}
+		}
+
+		fn()
+	}()
+}
+
+// Wait blocks until every task started with Go has returned.
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+// rateLimiter is a minimal token-bucket with a burst of 1: a caller can
+// never start sooner than 1/ratePerSecond after the previous start.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(ratePerSecond float64) *rateLimiter {
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / ratePerSecond)}
+}
+
+func (r *rateLimiter) wait(ctx context.Context) error {
+	r.mu.Lock()
+	now := time.Now()
+	wait := time.Duration(0)
+	if r.next.After(now) {
+		wait = r.next.Sub(now)
+	}
+	r.next = now.Add(wait).Add(r.interval)
+	r.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+		return nil
+	}
+}
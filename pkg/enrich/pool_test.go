@@ -0,0 +1,60 @@
+package enrich
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoolBoundsConcurrency(t *testing.T) {
+	p := NewPool(PoolConfig{Concurrency: 2})
+
+	var running, maxRunning int32
+	for range 6 {
+		p.Go(context.Background(), func() {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+	}
+	p.Wait()
+
+	if maxRunning > 2 {
+		t.Errorf("max concurrent tasks = %d, want <= 2", maxRunning)
+	}
+}
+
+func TestPoolGoSkipsOnCancelledContext(t *testing.T) {
+	p := NewPool(PoolConfig{Concurrency: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var ran bool
+	p.Go(ctx, func() { ran = true })
+	p.Wait()
+
+	if ran {
+		t.Error("expected fn to be skipped once context is already cancelled")
+	}
+}
+
+func TestRateLimiterSpacesCalls(t *testing.T) {
+	r := newRateLimiter(20) // one call every 50ms
+
+	start := time.Now()
+	for range 3 {
+		if err := r.wait(context.Background()); err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("elapsed = %v, want >= ~100ms for 3 calls at 20/s", elapsed)
+	}
+}
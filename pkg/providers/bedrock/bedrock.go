@@ -0,0 +1,249 @@
+// Package bedrock discovers Amazon Bedrock foundation models live via the
+// AWS SDK instead of relying solely on the static, checked-in catwalk
+// config: bedrockProvider (internal/providers) has to be hand-edited every
+// time AWS ships a new Nova/Claude/Llama revision, and BedrockDiscoverer
+// lets that list stay current between releases.
+package bedrock
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrock"
+	"github.com/aws/aws-sdk-go-v2/service/bedrock/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/catwalk/pkg/catwalk/catalog"
+)
+
+// BedrockAPI is the subset of *bedrock.Client BedrockDiscoverer calls,
+// narrowed to just those two methods (mirroring how pkg/catwalk/fetcher
+// exposes only Source) so tests can supply a fake instead of a real AWS
+// client.
+type BedrockAPI interface {
+	ListFoundationModels(ctx context.Context, params *bedrock.ListFoundationModelsInput, optFns ...func(*bedrock.Options)) (*bedrock.ListFoundationModelsOutput, error)
+	GetFoundationModel(ctx context.Context, params *bedrock.GetFoundationModelInput, optFns ...func(*bedrock.Options)) (*bedrock.GetFoundationModelOutput, error)
+}
+
+//go:embed pricing.yaml
+var pricingData []byte
+
+// reasoningCapablePrefixes allowlists the Bedrock model-ID prefixes known to
+// support controllable reasoning, since neither ListFoundationModels nor
+// GetFoundationModel report it.
+var reasoningCapablePrefixes = []string{
+	"anthropic.claude-3-7",
+	"anthropic.claude-opus-4",
+	"anthropic.claude-sonnet-4",
+	"amazon.nova-premier",
+}
+
+func canReason(modelID string) bool {
+	for _, prefix := range reasoningCapablePrefixes {
+		if strings.HasPrefix(modelID, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func supportsImages(modalities []types.ModelModality) bool {
+	return slices.Contains(modalities, types.ModelModalityImage)
+}
+
+// modalities converts AWS's modality enum to catwalk.Modality, dropping any
+// value catwalk doesn't model (there's no video modality in the Bedrock SDK
+// today, but leave room for it).
+func modalities(in []types.ModelModality) []catwalk.Modality {
+	out := make([]catwalk.Modality, 0, len(in))
+	for _, m := range in {
+		switch m {
+		case types.ModelModalityText:
+			out = append(out, catwalk.ModalityText)
+		case types.ModelModalityImage:
+			out = append(out, catwalk.ModalityImage)
+		}
+	}
+	return out
+}
+
+// defaultTTL is how long a cached Discover result is trusted before
+// querying AWS again.
+const defaultTTL = 24 * time.Hour
+
+// defaultCachePath returns where Discover persists its result by default:
+// under os.UserCacheDir, falling back to os.TempDir if that's unavailable.
+func defaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "catwalk", "bedrock-models.json")
+}
+
+// Option configures a BedrockDiscoverer constructed by NewDiscoverer.
+type Option func(*BedrockDiscoverer)
+
+// WithCachePath overrides where Discover caches its result on disk. An
+// empty path disables the cache.
+func WithCachePath(path string) Option {
+	return func(d *BedrockDiscoverer) { d.cachePath = path }
+}
+
+// WithTTL overrides how long a cached result is trusted before Discover
+// queries AWS again. A non-positive ttl disables the cache.
+func WithTTL(ttl time.Duration) Option {
+	return func(d *BedrockDiscoverer) { d.ttl = ttl }
+}
+
+// BedrockDiscoverer lists Bedrock foundation models via api and maps them
+// into catwalk.Model, caching the result on disk between calls.
+type BedrockDiscoverer struct {
+	api       BedrockAPI
+	cachePath string
+	ttl       time.Duration
+}
+
+// NewDiscoverer builds a BedrockDiscoverer that calls api, caching results
+// for 24h under defaultCachePath unless overridden with WithCachePath/WithTTL.
+func NewDiscoverer(api BedrockAPI, opts ...Option) *BedrockDiscoverer {
+	d := &BedrockDiscoverer{
+		api:       api,
+		cachePath: defaultCachePath(),
+		ttl:       defaultTTL,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// cacheEntry is the on-disk shape Discover reads/writes at d.cachePath.
+type cacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Models    []catwalk.Model `json:"models"`
+}
+
+// Discover returns Bedrock's current foundation-model catalog, mapped to
+// catwalk.Model and overlaid with the checked-in pricing.yaml (AWS doesn't
+// return pricing). A result cached within d.ttl is served from d.cachePath
+// without calling AWS; otherwise ListFoundationModels lists candidates and
+// GetFoundationModel fills in the modality/lifecycle details each is mapped
+// from, and the merged result is cached for next time.
+func (d *BedrockDiscoverer) Discover(ctx context.Context) ([]catwalk.Model, error) {
+	if cached, ok := d.readCache(); ok {
+		return cached, nil
+	}
+
+	out, err := d.api.ListFoundationModels(ctx, &bedrock.ListFoundationModelsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: listing foundation models: %w", err)
+	}
+
+	models := make([]catwalk.Model, 0, len(out.ModelSummaries))
+	for _, summary := range out.ModelSummaries {
+		model, ok, err := d.normalize(ctx, summary)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			models = append(models, model)
+		}
+	}
+
+	overlay, err := pricingOverlay()
+	if err != nil {
+		return nil, err
+	}
+	merged := catalog.Merge([]catwalk.Provider{{ID: catwalk.InferenceProviderBedrock, Models: models}}, overlay)
+	models = merged[0].Models
+
+	d.writeCache(models)
+	return models, nil
+}
+
+// normalize fetches summary's full details via GetFoundationModel and maps
+// them to a catwalk.Model. ok is false for a model that's no longer active
+// (withdrawn/legacy) or has no ID to key off of.
+func (d *BedrockDiscoverer) normalize(ctx context.Context, summary types.FoundationModelSummary) (catwalk.Model, bool, error) {
+	if summary.ModelId == nil {
+		return catwalk.Model{}, false, nil
+	}
+
+	out, err := d.api.GetFoundationModel(ctx, &bedrock.GetFoundationModelInput{ModelIdentifier: summary.ModelId})
+	if err != nil {
+		return catwalk.Model{}, false, fmt.Errorf("bedrock: getting foundation model %s: %w", *summary.ModelId, err)
+	}
+	if out.ModelDetails == nil {
+		return catwalk.Model{}, false, nil
+	}
+	detail := *out.ModelDetails
+
+	if detail.ModelLifecycle != nil && detail.ModelLifecycle.Status != types.FoundationModelLifecycleStatusActive {
+		return catwalk.Model{}, false, nil
+	}
+
+	id := aws.ToString(detail.ModelId)
+	return catwalk.Model{
+		ID:             id,
+		Name:           aws.ToString(detail.ModelName),
+		SupportsImages: supportsImages(detail.InputModalities),
+		CanReason:      canReason(id),
+		Capabilities: catwalk.Capabilities{
+			InputModalities:  modalities(detail.InputModalities),
+			OutputModalities: modalities(detail.OutputModalities),
+		},
+	}, true, nil
+}
+
+func pricingOverlay() (catalog.Overlay, error) {
+	var overlay catalog.Overlay
+	if err := yaml.Unmarshal(pricingData, &overlay); err != nil {
+		return nil, fmt.Errorf("bedrock: parsing pricing overlay: %w", err)
+	}
+	return overlay, nil
+}
+
+func (d *BedrockDiscoverer) readCache() ([]catwalk.Model, bool) {
+	if d.cachePath == "" || d.ttl <= 0 {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(d.cachePath)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if time.Since(entry.FetchedAt) > d.ttl {
+		return nil, false
+	}
+	return entry.Models, true
+}
+
+func (d *BedrockDiscoverer) writeCache(models []catwalk.Model) {
+	if d.cachePath == "" {
+		return
+	}
+
+	data, err := json.Marshal(cacheEntry{FetchedAt: time.Now(), Models: models})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(d.cachePath), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(d.cachePath, data, 0o600)
+}
@@ -0,0 +1,165 @@
+package bedrock
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrock"
+	"github.com/aws/aws-sdk-go-v2/service/bedrock/types"
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+)
+
+// fakeBedrockAPI is a BedrockAPI backed by canned summaries/details instead
+// of a real AWS Bedrock client.
+type fakeBedrockAPI struct {
+	summaries []types.FoundationModelSummary
+	details   map[string]types.FoundationModelDetails
+	getCalls  int
+	listCalls int
+}
+
+func (f *fakeBedrockAPI) ListFoundationModels(context.Context, *bedrock.ListFoundationModelsInput, ...func(*bedrock.Options)) (*bedrock.ListFoundationModelsOutput, error) {
+	f.listCalls++
+	return &bedrock.ListFoundationModelsOutput{ModelSummaries: f.summaries}, nil
+}
+
+func (f *fakeBedrockAPI) GetFoundationModel(_ context.Context, params *bedrock.GetFoundationModelInput, _ ...func(*bedrock.Options)) (*bedrock.GetFoundationModelOutput, error) {
+	f.getCalls++
+	detail, ok := f.details[aws.ToString(params.ModelIdentifier)]
+	if !ok {
+		return &bedrock.GetFoundationModelOutput{}, nil
+	}
+	return &bedrock.GetFoundationModelOutput{ModelDetails: &detail}, nil
+}
+
+// novaFakeAPI returns a fakeBedrockAPI seeded with the four Nova models,
+// matching what the checked-in pricing.yaml has entries for.
+func novaFakeAPI() *fakeBedrockAPI {
+	nova := []struct {
+		id             string
+		name           string
+		supportsImages bool
+	}{
+		{"amazon.nova-pro-v1:0", "Amazon Nova Pro", true},
+		{"amazon.nova-lite-v1:0", "Amazon Nova Lite", true},
+		{"amazon.nova-micro-v1:0", "Amazon Nova Micro", false},
+		{"amazon.nova-premier-v1:0", "Amazon Nova Premier", true},
+	}
+
+	api := &fakeBedrockAPI{details: make(map[string]types.FoundationModelDetails, len(nova))}
+	for _, m := range nova {
+		modalities := []types.ModelModality{types.ModelModalityText}
+		if m.supportsImages {
+			modalities = append(modalities, types.ModelModalityImage)
+		}
+
+		api.summaries = append(api.summaries, types.FoundationModelSummary{ModelId: aws.String(m.id)})
+		api.details[m.id] = types.FoundationModelDetails{
+			ModelId:         aws.String(m.id),
+			ModelName:       aws.String(m.name),
+			InputModalities: modalities,
+			ModelLifecycle:  &types.FoundationModelLifecycle{Status: types.FoundationModelLifecycleStatusActive},
+		}
+	}
+	return api
+}
+
+func TestDiscoverMapsNovaModels(t *testing.T) {
+	api := novaFakeAPI()
+	models, err := NewDiscoverer(api, WithCachePath("")).Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	novaModelPattern := regexp.MustCompile(`^amazon\.nova-[a-z]+-v\d+:\d+$`)
+	want := map[string]struct {
+		costIn, costOut  float64
+		contextWindow    int64
+		defaultMaxTokens int64
+		supportsImages   bool
+		canReason        bool
+	}{
+		"amazon.nova-pro-v1:0":     {0.8, 3.2, 300000, 5000, true, false},
+		"amazon.nova-lite-v1:0":    {0.06, 0.24, 300000, 5000, true, false},
+		"amazon.nova-micro-v1:0":   {0.035, 0.14, 128000, 5000, false, false},
+		"amazon.nova-premier-v1:0": {2.5, 12.5, 300000, 5000, true, true},
+	}
+
+	if len(models) != len(want) {
+		t.Fatalf("Discover() returned %d models, want %d", len(models), len(want))
+	}
+
+	for _, m := range models {
+		t.Run(m.ID, func(t *testing.T) {
+			if !novaModelPattern.MatchString(m.ID) {
+				t.Errorf("ID %q does not match amazon.nova-*-v*:*", m.ID)
+			}
+			want, ok := want[m.ID]
+			if !ok {
+				t.Fatalf("unexpected model %q", m.ID)
+			}
+			if m.CostPer1MIn != want.costIn || m.CostPer1MOut != want.costOut {
+				t.Errorf("pricing = (%v, %v), want (%v, %v)", m.CostPer1MIn, m.CostPer1MOut, want.costIn, want.costOut)
+			}
+			if m.ContextWindow != want.contextWindow {
+				t.Errorf("ContextWindow = %d, want %d", m.ContextWindow, want.contextWindow)
+			}
+			if m.DefaultMaxTokens != want.defaultMaxTokens {
+				t.Errorf("DefaultMaxTokens = %d, want %d", m.DefaultMaxTokens, want.defaultMaxTokens)
+			}
+			if m.SupportsImages != want.supportsImages {
+				t.Errorf("SupportsImages = %v, want %v", m.SupportsImages, want.supportsImages)
+			}
+			if m.CanReason != want.canReason {
+				t.Errorf("CanReason = %v, want %v", m.CanReason, want.canReason)
+			}
+			if m.Supports(catwalk.CapabilityImageInput) != want.supportsImages {
+				t.Errorf("Supports(CapabilityImageInput) = %v, want %v", m.Supports(catwalk.CapabilityImageInput), want.supportsImages)
+			}
+			if !m.Supports(catwalk.CapabilityTools) {
+				t.Errorf("Supports(CapabilityTools) = false, want true (pricing.yaml sets supports_tools for every Nova model)")
+			}
+		})
+	}
+}
+
+func TestDiscoverSkipsInactiveModels(t *testing.T) {
+	api := novaFakeAPI()
+	api.summaries = append(api.summaries, types.FoundationModelSummary{ModelId: aws.String("amazon.nova-retired-v1:0")})
+	api.details["amazon.nova-retired-v1:0"] = types.FoundationModelDetails{
+		ModelId:        aws.String("amazon.nova-retired-v1:0"),
+		ModelName:      aws.String("Amazon Nova Retired"),
+		ModelLifecycle: &types.FoundationModelLifecycle{Status: types.FoundationModelLifecycleStatusLegacy},
+	}
+
+	models, err := NewDiscoverer(api, WithCachePath("")).Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	for _, m := range models {
+		if m.ID == "amazon.nova-retired-v1:0" {
+			t.Fatalf("Discover() included legacy model %q", m.ID)
+		}
+	}
+}
+
+func TestDiscoverUsesDiskCache(t *testing.T) {
+	api := novaFakeAPI()
+	cachePath := filepath.Join(t.TempDir(), "bedrock-models.json")
+	d := NewDiscoverer(api, WithCachePath(cachePath), WithTTL(time.Hour))
+
+	if _, err := d.Discover(context.Background()); err != nil {
+		t.Fatalf("Discover() first call error = %v", err)
+	}
+	if _, err := d.Discover(context.Background()); err != nil {
+		t.Fatalf("Discover() second call error = %v", err)
+	}
+
+	if api.listCalls != 1 {
+		t.Errorf("ListFoundationModels called %d times, want 1 (second Discover should hit the cache)", api.listCalls)
+	}
+}